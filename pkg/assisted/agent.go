@@ -65,6 +65,12 @@ func PullAgent(apiClient *clients.Settings, name, nsname string) (*agentBuilder,
 		},
 	}
 
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agent scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the agent is empty")
 
@@ -77,11 +83,17 @@ func PullAgent(apiClient *clients.Settings, name, nsname string) (*agentBuilder,
 		builder.errorMsg = "agent 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("agent object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -323,7 +335,7 @@ func (builder *agentBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Delete removes an agent from the cluster.
@@ -65,6 +65,12 @@ func NewAgentClusterInstallBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveextV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agentclusterinstall scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add agentclusterinstall scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the agentclusterinstall is empty")
 
@@ -574,6 +580,12 @@ func PullAgentClusterInstall(apiClient *clients.Settings, name, nsname string) (
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveextV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agentclusterinstall scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the agentclusterinstall is empty")
 
@@ -586,11 +598,17 @@ func PullAgentClusterInstall(apiClient *clients.Settings, name, nsname string) (
 		builder.errorMsg = "agentclusterinstall 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("agentclusterinstall object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -725,7 +743,7 @@ func (builder *AgentClusterInstallBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // newagentClusterInstallCondition creates a new instance of agentClusterInstallCondition.
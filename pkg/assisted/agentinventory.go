@@ -0,0 +1,271 @@
+package assisted
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/openshift/assisted-service/api/common"
+	agentInstallV1Beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	"github.com/openshift/assisted-service/models"
+)
+
+const (
+	// AgentValidationSuccess is the status of a passing agent validation.
+	AgentValidationSuccess = "success"
+	// AgentValidationFailure is the status of a failing agent validation.
+	AgentValidationFailure = "failure"
+	// AgentValidationPending is the status of an agent validation waiting for data to be evaluated.
+	AgentValidationPending = "pending"
+)
+
+// AgentDiskSelector provides struct for the criteria used to pick a disk from the agent inventory.
+// Zero values do not restrict the selection.
+type AgentDiskSelector struct {
+	MinSizeBytes int64
+	MaxSizeBytes int64
+	DriveTypes   []models.DriveType
+	// IncludeIneligible also selects disks the agent reports as not eligible for installation.
+	IncludeIneligible bool
+}
+
+// GetInventory returns the hardware inventory discovered by the agent.
+func (builder *agentBuilder) GetInventory() (*agentInstallV1Beta1.HostInventory, error) {
+	status, err := builder.getStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := status.Inventory
+
+	if inventory.Cpu.Count == 0 && len(inventory.Disks) == 0 && len(inventory.Interfaces) == 0 {
+		glog.V(100).Infof("Agent %s in namespace %s has not reported its inventory yet",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, fmt.Errorf("agent %s in namespace %s has not reported its inventory yet",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return &inventory, nil
+}
+
+// GetCPU returns the CPU details of the agent inventory.
+func (builder *agentBuilder) GetCPU() (*agentInstallV1Beta1.HostCPU, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.Cpu, nil
+}
+
+// GetMemory returns the memory details of the agent inventory.
+func (builder *agentBuilder) GetMemory() (*agentInstallV1Beta1.HostMemory, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.Memory, nil
+}
+
+// GetDisks returns the disks of the agent inventory with their sizes, types and installation eligibility.
+func (builder *agentBuilder) GetDisks() ([]agentInstallV1Beta1.HostDisk, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.Disks, nil
+}
+
+// GetInterfaces returns the network interfaces of the agent inventory with their MACs, IPs and speeds.
+func (builder *agentBuilder) GetInterfaces() ([]agentInstallV1Beta1.HostInterface, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	return inventory.Interfaces, nil
+}
+
+// GetInterfaceByMAC returns the network interface of the agent inventory with the given MAC address.
+func (builder *agentBuilder) GetInterfaceByMAC(macAddress string) (*agentInstallV1Beta1.HostInterface, error) {
+	interfaces, err := builder.GetInterfaces()
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range interfaces {
+		if interfaces[index].MacAddress == macAddress {
+			return &interfaces[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("agent %s in namespace %s has no interface with mac address %s",
+		builder.Definition.Name, builder.Definition.Namespace, macAddress)
+}
+
+// GetBMCAddress returns the BMC address of the agent inventory, the IPv4 address when both families are reported.
+func (builder *agentBuilder) GetBMCAddress() (string, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return "", err
+	}
+
+	if inventory.BmcAddress != "" && inventory.BmcAddress != "0.0.0.0" {
+		return inventory.BmcAddress, nil
+	}
+
+	if inventory.BmcV6address != "" && inventory.BmcV6address != "::/0" {
+		return inventory.BmcV6address, nil
+	}
+
+	return "", fmt.Errorf("agent %s in namespace %s reports no BMC address",
+		builder.Definition.Name, builder.Definition.Namespace)
+}
+
+// GetSystemVendor returns the system vendor details of the agent inventory.
+func (builder *agentBuilder) GetSystemVendor() (*agentInstallV1Beta1.HostSystemVendor, error) {
+	inventory, err := builder.GetInventory()
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.SystemVendor, nil
+}
+
+// GetValidationsInfo returns the validation results of the agent grouped by category.
+func (builder *agentBuilder) GetValidationsInfo() (common.ValidationsStatus, error) {
+	status, err := builder.getStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	return status.ValidationsInfo, nil
+}
+
+// GetFailedValidations returns the validation results of the agent with a failure status.
+func (builder *agentBuilder) GetFailedValidations() ([]common.ValidationResult, error) {
+	validationsInfo, err := builder.GetValidationsInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	var failedValidations []common.ValidationResult
+
+	for _, results := range validationsInfo {
+		for _, result := range results {
+			if result.Status == AgentValidationFailure {
+				failedValidations = append(failedValidations, result)
+			}
+		}
+	}
+
+	sort.Slice(failedValidations, func(i, j int) bool {
+		return failedValidations[i].ID < failedValidations[j].ID
+	})
+
+	return failedValidations, nil
+}
+
+// SelectDisks returns the disks of the agent inventory matching the selector, ordered by size and ID.
+func (builder *agentBuilder) SelectDisks(selector AgentDiskSelector) ([]agentInstallV1Beta1.HostDisk, error) {
+	disks, err := builder.GetDisks()
+	if err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Selecting disks of agent %s in namespace %s matching %+v",
+		builder.Definition.Name, builder.Definition.Namespace, selector)
+
+	var selectedDisks []agentInstallV1Beta1.HostDisk
+
+	for _, disk := range disks {
+		if selector.matches(disk) {
+			selectedDisks = append(selectedDisks, disk)
+		}
+	}
+
+	sort.SliceStable(selectedDisks, func(i, j int) bool {
+		if selectedDisks[i].SizeBytes != selectedDisks[j].SizeBytes {
+			return selectedDisks[i].SizeBytes < selectedDisks[j].SizeBytes
+		}
+
+		return selectedDisks[i].ID < selectedDisks[j].ID
+	})
+
+	return selectedDisks, nil
+}
+
+// WithInstallationDiskSelector sets the installationDiskID of the agent to the smallest disk matching the selector.
+func (builder *agentBuilder) WithInstallationDiskSelector(selector AgentDiskSelector) *agentBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Selecting installation disk of agent %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	disks, err := builder.SelectDisks(selector)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	if len(disks) == 0 {
+		glog.V(100).Infof("No disk of agent %s in namespace %s matches the selector",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		builder.errorMsg = fmt.Sprintf("no disk of agent %s matches the installation disk selector",
+			builder.Definition.Name)
+
+		return builder
+	}
+
+	return builder.WithInstallationDisk(disks[0].ID)
+}
+
+// getStatus refreshes the agent object from the cluster and returns its status.
+func (builder *agentBuilder) getStatus() (*agentInstallV1Beta1.AgentStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	agent, err := builder.Get()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent %s in namespace %s: %w",
+			builder.Definition.Name, builder.Definition.Namespace, err)
+	}
+
+	builder.Object = agent
+
+	return &agent.Status, nil
+}
+
+func (selector AgentDiskSelector) matches(disk agentInstallV1Beta1.HostDisk) bool {
+	if !selector.IncludeIneligible && !disk.InstallationEligibility.Eligible {
+		return false
+	}
+
+	if selector.MinSizeBytes > 0 && disk.SizeBytes < selector.MinSizeBytes {
+		return false
+	}
+
+	if selector.MaxSizeBytes > 0 && disk.SizeBytes > selector.MaxSizeBytes {
+		return false
+	}
+
+	if len(selector.DriveTypes) == 0 {
+		return true
+	}
+
+	for _, driveType := range selector.DriveTypes {
+		if disk.DriveType == string(driveType) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -59,6 +59,12 @@ func NewAgentServiceConfigBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agentserviceconfig scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add agentserviceconfig scheme to client schemes: %v", err)
+	}
+
 	return &builder
 }
 
@@ -78,6 +84,12 @@ func NewDefaultAgentServiceConfigBuilder(apiClient *clients.Settings) *AgentServ
 		},
 	}
 
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agentserviceconfig scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add agentserviceconfig scheme to client schemes: %v", err)
+	}
+
 	imageStorageSpec, err := GetDefaultStorageSpec(defaultImageStoreStorageSize)
 	if err != nil {
 		glog.V(100).Infof("The ImageStorage size is in wrong format")
@@ -282,11 +294,23 @@ func PullAgentServiceConfig(apiClient *clients.Settings) (*AgentServiceConfigBui
 		},
 	}
 
-	if !builder.Exists() {
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add agentserviceconfig scheme to client schemes")
+
+		return nil, err
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("agentserviceconfig object %s doesn't exist", agentServiceConfigName)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -447,7 +471,7 @@ func (builder *AgentServiceConfigBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // GetDefaultStorageSpec returns a default PVC spec for the respective
@@ -60,6 +60,13 @@ func NewInfraEnvBuilder(apiClient *clients.Settings, name, nsname, psName string
 		},
 	}
 
+	if err := apiClient.AttachScheme(
+		agentInstallV1Beta1.AddToScheme, hiveV1.AddToScheme, hiveextV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add infraenv scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add infraenv scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the infraenv is empty")
 
@@ -712,6 +719,13 @@ func PullInfraEnvInstall(apiClient *clients.Settings, name, nsname string) (*Inf
 		},
 	}
 
+	if err := apiClient.AttachScheme(
+		agentInstallV1Beta1.AddToScheme, hiveV1.AddToScheme, hiveextV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add infraenv scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the infraenv is empty")
 
@@ -724,11 +738,17 @@ func PullInfraEnvInstall(apiClient *clients.Settings, name, nsname string) (*Inf
 		builder.errorMsg = "infraenv 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("infraenv object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -839,7 +859,7 @@ func (builder *InfraEnvBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // validate will check that the builder and builder definition are properly initialized before
@@ -0,0 +1,461 @@
+package assisted
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"github.com/openshift-kni/eco-goinfra/pkg/nmstate"
+	agentInstallV1Beta1 "github.com/openshift/assisted-service/api/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// NMStateConfigBuilder provides struct for the nmstateconfig object containing connection to
+// the cluster and the nmstateconfig definitions.
+type NMStateConfigBuilder struct {
+	Definition *agentInstallV1Beta1.NMStateConfig
+	Object     *agentInstallV1Beta1.NMStateConfig
+	errorMsg   string
+	apiClient  *clients.Settings
+	// infraEnvSelector is the nmstateconfig label selector of the infraenv the nmstateconfig is defined for.
+	infraEnvSelector *metaV1.LabelSelector
+}
+
+// NMStateConfigAdditionalOptions additional options for nmstateconfig object.
+type NMStateConfigAdditionalOptions func(builder *NMStateConfigBuilder) (*NMStateConfigBuilder, error)
+
+// NewNMStateConfigBuilder creates a new instance of NMStateConfigBuilder.
+func NewNMStateConfigBuilder(
+	apiClient *clients.Settings, name, nsname string, nmstateLabels map[string]string) *NMStateConfigBuilder {
+	glog.V(100).Infof(
+		"Initializing new nmstateconfig structure with the following params: name: %s, namespace: %s, labels: %v",
+		name, nsname, nmstateLabels)
+
+	builder := NMStateConfigBuilder{
+		apiClient: apiClient,
+		Definition: &agentInstallV1Beta1.NMStateConfig{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+				Labels:    nmstateLabels,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add nmstateconfig scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add nmstateconfig scheme to client schemes: %v", err)
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the nmstateconfig is empty")
+
+		builder.errorMsg = "nmstateconfig 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the nmstateconfig is empty")
+
+		builder.errorMsg = "nmstateconfig 'namespace' cannot be empty"
+	}
+
+	if len(nmstateLabels) == 0 {
+		glog.V(100).Infof("The labels of the nmstateconfig are empty")
+
+		builder.errorMsg = "nmstateconfig 'labels' cannot be empty"
+	}
+
+	return &builder
+}
+
+// PullNMStateConfig pulls existing nmstateconfig from cluster.
+func PullNMStateConfig(apiClient *clients.Settings, name, nsname string) (*NMStateConfigBuilder, error) {
+	glog.V(100).Infof("Pulling existing nmstateconfig name %s under namespace %s from cluster", name, nsname)
+
+	builder := NMStateConfigBuilder{
+		apiClient: apiClient,
+		Definition: &agentInstallV1Beta1.NMStateConfig{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(agentInstallV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add nmstateconfig scheme to client schemes")
+
+		return nil, err
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the nmstateconfig is empty")
+
+		builder.errorMsg = "nmstateconfig 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the nmstateconfig is empty")
+
+		builder.errorMsg = "nmstateconfig 'namespace' cannot be empty"
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("nmstateconfig object %s doesn't exist in namespace %s", name, nsname)
+	}
+
+	builder.Object = object
+	builder.Definition = object
+
+	return &builder, nil
+}
+
+// WithInterface maps the interface name used in the desired state to the MAC address of the host.
+func (builder *NMStateConfigBuilder) WithInterface(name, macAddress string) *NMStateConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding interface %s with mac address %s to nmstateconfig %s",
+		name, macAddress, builder.Definition.Name)
+
+	if name == "" {
+		glog.V(100).Infof("The name of the nmstateconfig interface is empty")
+
+		builder.errorMsg = "nmstateconfig interface 'name' cannot be empty"
+
+		return builder
+	}
+
+	if hardwareAddr, err := net.ParseMAC(macAddress); err != nil || len(hardwareAddr) != 6 {
+		glog.V(100).Infof("The mac address %s of the nmstateconfig interface is invalid", macAddress)
+
+		builder.errorMsg = fmt.Sprintf("nmstateconfig interface %s has invalid mac address %s", name, macAddress)
+
+		return builder
+	}
+
+	for _, nmstateInterface := range builder.Definition.Spec.Interfaces {
+		if nmstateInterface.Name == name {
+			builder.errorMsg = fmt.Sprintf("nmstateconfig interface %s is already defined", name)
+
+			return builder
+		}
+
+		if nmstateInterface.MacAddress == macAddress {
+			builder.errorMsg = fmt.Sprintf("mac address %s is already mapped to nmstateconfig interface %s",
+				macAddress, nmstateInterface.Name)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Interfaces = append(builder.Definition.Spec.Interfaces,
+		&agentInstallV1Beta1.Interface{Name: name, MacAddress: macAddress})
+
+	return builder
+}
+
+// WithDesiredState sets the network configuration applied to the host to the given nmstate desired state.
+func (builder *NMStateConfigBuilder) WithDesiredState(desiredState nmstate.DesiredState) *NMStateConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting desired state of nmstateconfig %s", builder.Definition.Name)
+
+	desiredStateYaml, err := yaml.Marshal(desiredState)
+	if err != nil {
+		glog.V(100).Infof("Failed Marshal DesiredState")
+
+		builder.errorMsg = fmt.Sprintf("failed to marshal nmstateconfig desired state: %v", err)
+
+		return builder
+	}
+
+	builder.Definition.Spec.NetConfig = agentInstallV1Beta1.NetConfig{Raw: desiredStateYaml}
+
+	return builder
+}
+
+// WithInfraEnv verifies on Create and Update that the labels of the nmstateconfig match
+// the nmstateconfig label selector of the given infraenv.
+func (builder *NMStateConfigBuilder) WithInfraEnv(infraEnv *InfraEnvBuilder) *NMStateConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	if valid, err := infraEnv.validate(); !valid {
+		builder.errorMsg = fmt.Sprintf("invalid infraenv for nmstateconfig: %v", err)
+
+		return builder
+	}
+
+	glog.V(100).Infof("Binding nmstateconfig %s to infraenv %s",
+		builder.Definition.Name, infraEnv.Definition.Name)
+
+	if infraEnv.Definition.Namespace != builder.Definition.Namespace {
+		builder.errorMsg = fmt.Sprintf("infraenv %s is in namespace %s, not in nmstateconfig namespace %s",
+			infraEnv.Definition.Name, infraEnv.Definition.Namespace, builder.Definition.Namespace)
+
+		return builder
+	}
+
+	builder.infraEnvSelector = infraEnv.Definition.Spec.NMStateConfigLabelSelector.DeepCopy()
+
+	return builder
+}
+
+// WithOptions creates nmstateconfig with generic mutation options.
+func (builder *NMStateConfigBuilder) WithOptions(options ...NMStateConfigAdditionalOptions) *NMStateConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting nmstateconfig additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// GetDesiredState returns the network configuration of the nmstateconfig as nmstate desired state.
+func (builder *NMStateConfigBuilder) GetDesiredState() (*nmstate.DesiredState, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	desiredState := &nmstate.DesiredState{}
+
+	if err := yaml.Unmarshal(builder.Definition.Spec.NetConfig.Raw, desiredState); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal nmstateconfig %s desired state: %w", builder.Definition.Name, err)
+	}
+
+	return desiredState, nil
+}
+
+// Get fetches the defined nmstateconfig from the cluster.
+func (builder *NMStateConfigBuilder) Get() (*agentInstallV1Beta1.NMStateConfig, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting nmstateconfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	nmstateConfig := &agentInstallV1Beta1.NMStateConfig{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, nmstateConfig)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return nmstateConfig, err
+}
+
+// Create generates a nmstateconfig on the cluster.
+func (builder *NMStateConfigBuilder) Create() (*NMStateConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the nmstateconfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if err := builder.validateSpec(); err != nil {
+		return builder, err
+	}
+
+	var err error
+	if !builder.Exists() {
+		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+		if err == nil {
+			builder.Object = builder.Definition
+		}
+	}
+
+	return builder, err
+}
+
+// Update modifies an existing nmstateconfig on the cluster.
+func (builder *NMStateConfigBuilder) Update() (*NMStateConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating nmstateconfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		glog.V(100).Infof("nmstateconfig %s in namespace %s does not exist",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		builder.errorMsg = "Cannot update non-existent nmstateconfig"
+	}
+
+	if builder.errorMsg != "" {
+		return nil, fmt.Errorf(builder.errorMsg)
+	}
+
+	if err := builder.validateSpec(); err != nil {
+		return builder, err
+	}
+
+	if builder.Object != nil {
+		builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	}
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err == nil {
+		builder.Object = builder.Definition
+	}
+
+	return builder, err
+}
+
+// Delete removes a nmstateconfig from the cluster.
+func (builder *NMStateConfigBuilder) Delete() (*NMStateConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Deleting the nmstateconfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("nmstateconfig cannot be deleted because it does not exist")
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+
+	if err != nil {
+		return builder, fmt.Errorf("cannot delete nmstateconfig: %w", err)
+	}
+
+	builder.Object = nil
+	builder.Definition.ResourceVersion = ""
+
+	return builder, nil
+}
+
+// Exists checks if the defined nmstateconfig has already been created.
+func (builder *NMStateConfigBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if nmstateconfig %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
+}
+
+// validateSpec checks that the nmstateconfig identifies its host, that every ethernet interface of the desired
+// state without explicit mac address is mapped to one and that the labels match the infraenv selector.
+func (builder *NMStateConfigBuilder) validateSpec() error {
+	if len(builder.Definition.Spec.Interfaces) == 0 {
+		return fmt.Errorf("nmstateconfig %s must map at least one interface to a mac address",
+			builder.Definition.Name)
+	}
+
+	desiredState, err := builder.GetDesiredState()
+	if err != nil {
+		return err
+	}
+
+	mappedInterfaces := make(map[string]bool)
+
+	for _, nmstateInterface := range builder.Definition.Spec.Interfaces {
+		mappedInterfaces[nmstateInterface.Name] = true
+	}
+
+	for _, networkInterface := range desiredState.Interfaces {
+		if networkInterface.Type == "ethernet" && networkInterface.MacAddress == "" &&
+			!mappedInterfaces[networkInterface.Name] {
+			return fmt.Errorf("ethernet interface %s of nmstateconfig %s is not mapped to a mac address",
+				networkInterface.Name, builder.Definition.Name)
+		}
+	}
+
+	if builder.infraEnvSelector == nil {
+		return nil
+	}
+
+	selector, err := metaV1.LabelSelectorAsSelector(builder.infraEnvSelector)
+	if err != nil {
+		return fmt.Errorf("invalid nmstateconfig label selector of infraenv: %w", err)
+	}
+
+	// An empty selector of an infraenv selects no nmstateconfig.
+	if selector.Empty() || !selector.Matches(labels.Set(builder.Definition.Labels)) {
+		return fmt.Errorf("labels %v of nmstateconfig %s do not match infraenv nmstateconfig label selector %s",
+			builder.Definition.Labels, builder.Definition.Name, selector.String())
+	}
+
+	return nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *NMStateConfigBuilder) validate() (bool, error) {
+	resourceCRD := "NMStateConfig"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
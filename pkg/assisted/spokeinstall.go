@@ -0,0 +1,386 @@
+package assisted
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/bmh"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/hive"
+	"github.com/openshift-kni/eco-goinfra/pkg/namespace"
+	"github.com/openshift-kni/eco-goinfra/pkg/secret"
+	hiveextV1Beta1 "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
+	"github.com/openshift/assisted-service/models"
+	hiveV1 "github.com/openshift/hive/apis/hive/v1"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	spokeClusterNameLabel   = "agent-install.openshift.io/clusterdeployment-name"
+	bmacRoleAnnotation      = "bmac.agent-install.openshift.io/role"
+	bmacHostnameAnnotation  = "bmac.agent-install.openshift.io/hostname"
+	inspectAnnotation       = "inspect.metal3.io"
+	defaultSpokeBootMode    = "UEFI"
+	spokeInstallPollingRate = 10 * time.Second
+)
+
+// SpokeHostSpec provides struct for a bare-metal host of a spoke cluster.
+type SpokeHostSpec struct {
+	Name           string
+	Hostname       string
+	Role           models.HostRole
+	BMCAddress     string
+	BMCUsername    string
+	BMCPassword    string
+	BootMACAddress string
+	// BootMode defaults to UEFI.
+	BootMode string
+}
+
+// SpokeClusterSpec provides struct for the declarative definition of an assisted installer spoke cluster.
+// All namespaced resources are created in a namespace named after the cluster.
+type SpokeClusterSpec struct {
+	Name         string
+	BaseDomain   string
+	ReleaseImage string
+	// PullSecret holds the content of the .dockerconfigjson used by the spoke cluster.
+	PullSecret   []byte
+	SSHPublicKey string
+	Networking   hiveextV1Beta1.Networking
+	APIVIP       string
+	IngressVIP   string
+	MasterCount  int
+	WorkerCount  int
+	Hosts        []SpokeHostSpec
+}
+
+// SpokeInstaller provides struct orchestrating the installation of a spoke cluster with the assisted installer.
+// Builders of the created resources are available once Install progressed past their creation. The namespaced
+// resources are owned by the ClusterDeployment, so they are garbage collected with it. The namespace and the
+// cluster-scoped ClusterImageSet cannot be owned by it and have to be removed separately.
+type SpokeInstaller struct {
+	ClusterImageSet     *hive.ClusterImageSetBuilder
+	ClusterDeployment   *hive.ClusterDeploymentBuilder
+	AgentClusterInstall *AgentClusterInstallBuilder
+	InfraEnv            *InfraEnvBuilder
+	BareMetalHosts      []*bmh.Builder
+	spec                SpokeClusterSpec
+	apiClient           *clients.Settings
+	errorMsg            string
+}
+
+// NewSpokeInstaller creates a new instance of SpokeInstaller for the given spoke definition.
+func NewSpokeInstaller(apiClient *clients.Settings, spec SpokeClusterSpec) *SpokeInstaller {
+	glog.V(100).Infof("Initializing new spoke installer for cluster %s", spec.Name)
+
+	installer := &SpokeInstaller{apiClient: apiClient, spec: spec}
+
+	switch {
+	case apiClient == nil:
+		installer.errorMsg = "spoke installer cannot have nil apiClient"
+	case spec.Name == "":
+		installer.errorMsg = "spoke cluster 'name' cannot be empty"
+	case spec.BaseDomain == "":
+		installer.errorMsg = "spoke cluster 'baseDomain' cannot be empty"
+	case spec.ReleaseImage == "":
+		installer.errorMsg = "spoke cluster 'releaseImage' cannot be empty"
+	case len(spec.PullSecret) == 0:
+		installer.errorMsg = "spoke cluster 'pullSecret' cannot be empty"
+	case spec.MasterCount < 1:
+		installer.errorMsg = "spoke cluster requires at least one master"
+	case len(spec.Hosts) != spec.MasterCount+spec.WorkerCount:
+		installer.errorMsg = fmt.Sprintf("spoke cluster requires exactly %d hosts, %d defined",
+			spec.MasterCount+spec.WorkerCount, len(spec.Hosts))
+	}
+
+	return installer
+}
+
+// Install creates all resources of the spoke cluster in order, approves the registered agents, waits for the
+// AgentClusterInstall to complete and returns a client for the installed spoke cluster. The timeout applies
+// to each waiting step separately.
+func (installer *SpokeInstaller) Install(timeout time.Duration) (*clients.Settings, error) {
+	if installer == nil {
+		return nil, fmt.Errorf("error: received nil spoke installer")
+	}
+
+	if installer.errorMsg != "" {
+		return nil, fmt.Errorf(installer.errorMsg)
+	}
+
+	glog.V(100).Infof("Installing spoke cluster %s", installer.spec.Name)
+
+	if err := installer.createPrerequisites(); err != nil {
+		return nil, err
+	}
+
+	if err := installer.createClusterResources(); err != nil {
+		return nil, err
+	}
+
+	if _, err := installer.InfraEnv.WaitForDiscoveryISOCreation(timeout); err != nil {
+		return nil, fmt.Errorf("discovery ISO of spoke cluster %s was not created: %w", installer.spec.Name, err)
+	}
+
+	if err := installer.createBareMetalHosts(); err != nil {
+		return nil, err
+	}
+
+	if err := installer.approveAgents(timeout); err != nil {
+		return nil, err
+	}
+
+	if err := installer.WaitUntilInstalled(timeout); err != nil {
+		return nil, err
+	}
+
+	return installer.GetSpokeAPIClient()
+}
+
+// WaitUntilInstalled waits for the duration of the defined timeout or until the AgentClusterInstall reports the
+// Completed condition. It stops early with an error naming the failing condition when the install fails.
+func (installer *SpokeInstaller) WaitUntilInstalled(timeout time.Duration) error {
+	if installer.AgentClusterInstall == nil {
+		return fmt.Errorf("cannot wait for install of spoke cluster without AgentClusterInstall")
+	}
+
+	glog.V(100).Infof("Waiting until spoke cluster %s is installed", installer.spec.Name)
+
+	var installErr error
+
+	err := wait.PollImmediate(spokeInstallPollingRate, timeout, func() (bool, error) {
+		agentClusterInstall, err := installer.AgentClusterInstall.Get()
+		if err != nil {
+			glog.V(100).Infof("Failed to get AgentClusterInstall due to %v", err)
+
+			return false, nil
+		}
+
+		for _, condition := range agentClusterInstall.Status.Conditions {
+			switch {
+			case condition.Type == hiveextV1Beta1.ClusterCompletedCondition && condition.Status == coreV1.ConditionTrue:
+				return true, nil
+			case condition.Type == hiveextV1Beta1.ClusterFailedCondition && condition.Status == coreV1.ConditionTrue:
+				installErr = fmt.Errorf("spoke cluster %s install failed: %s: %s",
+					installer.spec.Name, condition.Reason, condition.Message)
+
+				return false, installErr
+			}
+		}
+
+		glog.V(100).Infof("Spoke cluster %s install state: %s", installer.spec.Name,
+			agentClusterInstall.Status.DebugInfo.State)
+
+		return false, nil
+	})
+
+	if installErr != nil {
+		return installErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("spoke cluster %s is not installed: %w", installer.spec.Name, installer.firstFailingCondition())
+	}
+
+	return nil
+}
+
+// GetSpokeAPIClient returns a client for the spoke cluster built from its admin kubeconfig secret.
+func (installer *SpokeInstaller) GetSpokeAPIClient() (*clients.Settings, error) {
+	if installer.ClusterDeployment == nil {
+		return nil, fmt.Errorf("cannot get client of spoke cluster without ClusterDeployment")
+	}
+
+	return installer.ClusterDeployment.GetSpokeAPIClient()
+}
+
+func (installer *SpokeInstaller) createPrerequisites() error {
+	if _, err := namespace.NewBuilder(installer.apiClient, installer.spec.Name).Create(); err != nil {
+		return fmt.Errorf("failed to create spoke namespace: %w", err)
+	}
+
+	var err error
+
+	installer.ClusterImageSet, err = hive.NewClusterImageSetBuilder(
+		installer.apiClient, installer.spec.Name, installer.spec.ReleaseImage).Create()
+	if err != nil {
+		return fmt.Errorf("failed to create spoke clusterimageset: %w", err)
+	}
+
+	return nil
+}
+
+func (installer *SpokeInstaller) createClusterResources() error {
+	var err error
+
+	agentSelector := metaV1.LabelSelector{MatchLabels: map[string]string{spokeClusterNameLabel: installer.spec.Name}}
+
+	installer.ClusterDeployment, err = hive.NewABMClusterDeploymentBuilder(installer.apiClient, installer.spec.Name,
+		installer.spec.Name, installer.spec.Name, installer.spec.BaseDomain, installer.spec.Name, agentSelector).
+		WithPullSecret(installer.pullSecretName()).Create()
+	if err != nil {
+		return fmt.Errorf("failed to create spoke clusterdeployment: %w", err)
+	}
+
+	ownerReferences := installer.ownerReferences()
+
+	pullSecret := secret.NewBuilder(installer.apiClient, installer.pullSecretName(), installer.spec.Name,
+		coreV1.SecretTypeDockerConfigJson).
+		WithData(map[string][]byte{coreV1.DockerConfigJsonKey: installer.spec.PullSecret})
+
+	if pullSecret.Definition != nil {
+		pullSecret.Definition.OwnerReferences = ownerReferences
+	}
+
+	if _, err = pullSecret.Create(); err != nil {
+		return fmt.Errorf("failed to create spoke pull-secret: %w", err)
+	}
+
+	installer.AgentClusterInstall = NewAgentClusterInstallBuilder(installer.apiClient, installer.spec.Name,
+		installer.spec.Name, installer.spec.Name, installer.spec.MasterCount, installer.spec.WorkerCount,
+		installer.spec.Networking).
+		WithImageSet(installer.ClusterImageSet.Definition.Name).
+		WithSSHPublicKey(installer.spec.SSHPublicKey)
+
+	if installer.spec.APIVIP != "" {
+		installer.AgentClusterInstall.WithAPIVip(installer.spec.APIVIP)
+	}
+
+	if installer.spec.IngressVIP != "" {
+		installer.AgentClusterInstall.WithIngressVip(installer.spec.IngressVIP)
+	}
+
+	if installer.AgentClusterInstall.Definition != nil {
+		installer.AgentClusterInstall.Definition.OwnerReferences = ownerReferences
+	}
+
+	if _, err = installer.AgentClusterInstall.Create(); err != nil {
+		return fmt.Errorf("failed to create spoke agentclusterinstall: %w", err)
+	}
+
+	installer.InfraEnv = NewInfraEnvBuilder(installer.apiClient, installer.spec.Name, installer.spec.Name,
+		installer.pullSecretName()).
+		WithClusterRef(installer.spec.Name, installer.spec.Name).
+		WithSSHAuthorizedKey(installer.spec.SSHPublicKey).
+		WithAgentLabel(spokeClusterNameLabel, installer.spec.Name)
+
+	if installer.InfraEnv.Definition != nil {
+		installer.InfraEnv.Definition.OwnerReferences = ownerReferences
+	}
+
+	if _, err = installer.InfraEnv.Create(); err != nil {
+		return fmt.Errorf("failed to create spoke infraenv: %w", err)
+	}
+
+	return nil
+}
+
+func (installer *SpokeInstaller) createBareMetalHosts() error {
+	ownerReferences := installer.ownerReferences()
+
+	for _, host := range installer.spec.Hosts {
+		bmcSecretName := host.Name + "-bmc-secret"
+
+		bmcSecret := secret.NewBuilder(installer.apiClient, bmcSecretName, installer.spec.Name, coreV1.SecretTypeOpaque).
+			WithData(map[string][]byte{
+				"username": []byte(host.BMCUsername),
+				"password": []byte(host.BMCPassword),
+			})
+
+		if bmcSecret.Definition != nil {
+			bmcSecret.Definition.OwnerReferences = ownerReferences
+		}
+
+		if _, err := bmcSecret.Create(); err != nil {
+			return fmt.Errorf("failed to create bmc secret of host %s: %w", host.Name, err)
+		}
+
+		bootMode := host.BootMode
+		if bootMode == "" {
+			bootMode = defaultSpokeBootMode
+		}
+
+		bmhBuilder := bmh.NewBuilder(installer.apiClient, host.Name, installer.spec.Name, host.BMCAddress,
+			bmcSecretName, host.BootMACAddress, bootMode)
+
+		bmhBuilder.Definition.Labels = map[string]string{agentInfraEnvLabel: installer.InfraEnv.Definition.Name}
+		bmhBuilder.Definition.Annotations = map[string]string{inspectAnnotation: "disabled"}
+		bmhBuilder.Definition.OwnerReferences = ownerReferences
+
+		if host.Role != "" {
+			bmhBuilder.Definition.Annotations[bmacRoleAnnotation] = string(host.Role)
+		}
+
+		if host.Hostname != "" {
+			bmhBuilder.Definition.Annotations[bmacHostnameAnnotation] = host.Hostname
+		}
+
+		if _, err := bmhBuilder.Create(); err != nil {
+			return fmt.Errorf("failed to create baremetalhost %s: %w", host.Name, err)
+		}
+
+		installer.BareMetalHosts = append(installer.BareMetalHosts, bmhBuilder)
+	}
+
+	return nil
+}
+
+func (installer *SpokeInstaller) approveAgents(timeout time.Duration) error {
+	agents, err := installer.InfraEnv.WaitForAgentsToRegister(timeout)
+	if err != nil {
+		return fmt.Errorf("agents of spoke cluster %s did not register: %w", installer.spec.Name, err)
+	}
+
+	for _, agent := range agents {
+		if agent.Object.Spec.Approved {
+			continue
+		}
+
+		glog.V(100).Infof("Approving agent %s of spoke cluster %s", agent.Object.Name, installer.spec.Name)
+
+		if _, err := agent.WithApproval(true).Update(); err != nil {
+			return fmt.Errorf("failed to approve agent %s: %w", agent.Object.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// firstFailingCondition returns an error describing the first AgentClusterInstall condition
+// which blocks the install from progressing.
+func (installer *SpokeInstaller) firstFailingCondition() error {
+	agentClusterInstall, err := installer.AgentClusterInstall.Get()
+	if err != nil {
+		return err
+	}
+
+	for _, conditionType := range []string{
+		hiveextV1Beta1.ClusterSpecSyncedCondition,
+		hiveextV1Beta1.ClusterValidatedCondition,
+		hiveextV1Beta1.ClusterRequirementsMetCondition,
+		hiveextV1Beta1.ClusterCompletedCondition,
+	} {
+		for _, condition := range agentClusterInstall.Status.Conditions {
+			if condition.Type == conditionType && condition.Status != coreV1.ConditionTrue {
+				return fmt.Errorf("condition %s is %s: %s: %s",
+					condition.Type, condition.Status, condition.Reason, condition.Message)
+			}
+		}
+	}
+
+	return fmt.Errorf("install state is %s: %s",
+		agentClusterInstall.Status.DebugInfo.State, agentClusterInstall.Status.DebugInfo.StateInfo)
+}
+
+// ownerReferences returns the owner references making the ClusterDeployment the owner of a spoke resource.
+func (installer *SpokeInstaller) ownerReferences() []metaV1.OwnerReference {
+	return []metaV1.OwnerReference{*metaV1.NewControllerRef(installer.ClusterDeployment.Object,
+		hiveV1.SchemeGroupVersion.WithKind("ClusterDeployment"))}
+}
+
+func (installer *SpokeInstaller) pullSecretName() string {
+	return installer.spec.Name + "-pull-secret"
+}
@@ -62,6 +62,12 @@ func NewBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(bmhv1alpha1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add baremetalhost scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add baremetalhost scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the baremetalhost is empty")
 
@@ -377,6 +383,12 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		},
 	}
 
+	if err := apiClient.AttachScheme(bmhv1alpha1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add baremetalhost scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the baremetalhost is empty")
 
@@ -389,11 +401,17 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		builder.errorMsg = "baremetalhost 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("baremetalhost object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -454,7 +472,7 @@ func (builder *Builder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns bmh object if found.
@@ -6,27 +6,19 @@ import (
 	"os"
 
 	"github.com/golang/glog"
+	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/dynamic"
 
-	argocdOperatorv1alpha1 "github.com/argoproj-labs/argocd-operator/api/v1alpha1"
-	argocdScheme "github.com/argoproj/argo-cd/v2/pkg/apis/application/v1alpha1"
 	argocdClient "github.com/argoproj/argo-cd/v2/pkg/client/clientset/versioned/typed/application/v1alpha1"
-	bmhv1alpha1 "github.com/metal3-io/baremetal-operator/apis/metal3.io/v1alpha1"
-	performanceV2 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/performanceprofile/v2"
-
 	clientConfigV1 "github.com/openshift/client-go/config/clientset/versioned/typed/config/v1"
 	v1security "github.com/openshift/client-go/security/clientset/versioned/typed/security/v1"
-	mcv1 "github.com/openshift/machine-config-operator/pkg/apis/machineconfiguration.openshift.io/v1"
 	ptpV1 "github.com/openshift/ptp-operator/pkg/client/clientset/versioned/typed/ptp/v1"
-	olm2 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/scheme"
 
 	olmv1 "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/typed/operators/v1"
 	olm "github.com/operator-framework/operator-lifecycle-manager/pkg/api/client/clientset/versioned/typed/operators/v1alpha1"
 
-	pkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/apis/operators/v1"
 	clientPkgManifestV1 "github.com/operator-framework/operator-lifecycle-manager/pkg/package-server/client/clientset/versioned/typed/operators/v1"
 
-	apiExt "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	appsV1Client "k8s.io/client-go/kubernetes/typed/apps/v1"
 	networkV1Client "k8s.io/client-go/kubernetes/typed/networking/v1"
@@ -34,33 +26,20 @@ import (
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
 
-	netAttDefV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
 	clientNetAttDefV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/client/clientset/versioned/typed/k8s.cni.cncf.io/v1"
-	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
 
 	clientSrIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/pkg/client/clientset/versioned/typed/sriovnetwork/v1"
-	metalLbOperatorV1Beta1 "github.com/metallb/metallb-operator/api/v1beta1"
 
 	clientMachineConfigV1 "github.com/openshift/machine-config-operator/pkg/generated/clientset/versioned/typed/machineconfiguration.openshift.io/v1"
-	metalLbV1Beta1 "go.universe.tf/metallb/api/v1beta1"
-
-	nmstatev1 "github.com/nmstate/kubernetes-nmstate/api/v1"
-	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
 
-	operatorV1 "github.com/openshift/api/operator/v1"
-	hiveextV1Beta1 "github.com/openshift/assisted-service/api/hiveextension/v1beta1"
-	agentInstallV1Beta1 "github.com/openshift/assisted-service/api/v1beta1"
-	hiveV1 "github.com/openshift/hive/apis/hive/v1"
-	moduleV1Beta1 "github.com/rh-ecosystem-edge/kernel-module-management/api/v1beta1"
-	"k8s.io/client-go/kubernetes/scheme"
 	coreV1Client "k8s.io/client-go/kubernetes/typed/core/v1"
-
-	nvidiagpuv1 "github.com/NVIDIA/gpu-operator/api/v1"
-	nfdv1 "github.com/openshift/cluster-nfd-operator/api/v1"
 )
 
 // Settings provides the struct to talk with relevant API.
+// The typed, dynamic and discovery clients are created on their first use, the runtime client discovers
+// the API resources on its first request and returns a CRDNotInstalledError for kinds the cluster does not serve.
 type Settings struct {
 	KubeconfigPath string
 	coreV1Client.CoreV1Interface
@@ -80,10 +59,25 @@ type Settings struct {
 	argocdClient.ArgoprojV1alpha1Interface
 	olmv1.OperatorsV1Interface
 	PackageManifestInterface clientPkgManifestV1.OperatorsV1Interface
+	discoveryClient          discovery.DiscoveryInterface
 }
 
 // New returns a *Settings with the given kubeconfig.
+// Use NewFromKubeconfigPath to get the cause when the client cannot be built.
 func New(kubeconfig string) *Settings {
+	clientSet, err := NewFromKubeconfigPath(kubeconfig)
+	if err != nil {
+		log.Print(err.Error())
+
+		return nil
+	}
+
+	return clientSet
+}
+
+// NewFromKubeconfigPath returns a *Settings built from the kubeconfig at the given path. When the path is empty,
+// the KUBECONFIG environment variable is used and the in-cluster config when neither is set.
+func NewFromKubeconfigPath(kubeconfig string, options ...ConfigOption) (*Settings, error) {
 	var (
 		config *rest.Config
 		err    error
@@ -94,153 +88,160 @@ func New(kubeconfig string) *Settings {
 	}
 
 	if kubeconfig != "" {
-		log.Printf("Loading kube client config from path %q", kubeconfig)
+		glog.V(100).Infof("Loading kube client config from path %q", kubeconfig)
 		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 	} else {
-		log.Print("Using in-cluster kube client config")
+		glog.V(100).Infof("Using in-cluster kube client config")
 		config, err = rest.InClusterConfig()
 	}
 
 	if err != nil {
-		return nil
+		return nil, fmt.Errorf("failed to load kube client config: %w", err)
 	}
 
-	clientSet := &Settings{}
-	clientSet.CoreV1Interface = coreV1Client.NewForConfigOrDie(config)
-	clientSet.ConfigV1Interface = clientConfigV1.NewForConfigOrDie(config)
-	clientSet.MachineconfigurationV1Interface = clientMachineConfigV1.NewForConfigOrDie(config)
-	clientSet.AppsV1Interface = appsV1Client.NewForConfigOrDie(config)
-	clientSet.SriovnetworkV1Interface = clientSrIovV1.NewForConfigOrDie(config)
-	clientSet.NetworkingV1Client = *networkV1Client.NewForConfigOrDie(config)
-	clientSet.PtpV1Interface = ptpV1.NewForConfigOrDie(config)
-	clientSet.RbacV1Interface = rbacV1Client.NewForConfigOrDie(config)
-	clientSet.OperatorsV1alpha1Interface = olm.NewForConfigOrDie(config)
-	clientSet.K8sCniCncfIoV1Interface = clientNetAttDefV1.NewForConfigOrDie(config)
-	clientSet.Interface = dynamic.NewForConfigOrDie(config)
-	clientSet.OperatorsV1Interface = olmv1.NewForConfigOrDie(config)
-	clientSet.PackageManifestInterface = clientPkgManifestV1.NewForConfigOrDie(config)
-	clientSet.SecurityV1Interface = v1security.NewForConfigOrDie(config)
-	clientSet.ArgoprojV1alpha1Interface = argocdClient.NewForConfigOrDie(config)
-
-	clientSet.Config = config
-
-	crScheme := runtime.NewScheme()
-	err = SetScheme(crScheme)
-
+	clientSet, err := newSettings(config, options...)
 	if err != nil {
-		log.Print("Error to load apiClient scheme")
-
-		return nil
-	}
-
-	clientSet.Client, err = runtimeClient.New(config, runtimeClient.Options{
-		Scheme: crScheme,
-	})
-
-	if err != nil {
-		log.Print("Error to create apiClient")
-
-		return nil
+		return nil, err
 	}
 
 	clientSet.KubeconfigPath = kubeconfig
 
-	return clientSet
+	return clientSet, nil
 }
 
-// SetScheme returns mutated apiClient's scheme.
-//
-//nolint:funlen
-func SetScheme(crScheme *runtime.Scheme) error {
-	if err := scheme.AddToScheme(crScheme); err != nil {
-		return err
-	}
+// NewFromKubeconfig returns a *Settings built from the given in-memory kubeconfig content.
+func NewFromKubeconfig(kubeconfig []byte, options ...ConfigOption) (*Settings, error) {
+	glog.V(100).Infof("Loading kube client config from in-memory kubeconfig")
 
-	if err := netAttDefV1.SchemeBuilder.AddToScheme(crScheme); err != nil {
-		return err
+	if len(kubeconfig) == 0 {
+		return nil, fmt.Errorf("kubeconfig cannot be empty")
 	}
 
-	if err := srIovV1.AddToScheme(crScheme); err != nil {
-		return err
-	}
-
-	if err := mcv1.AddToScheme(crScheme); err != nil {
-		return err
+	config, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load kubeconfig: %w", err)
 	}
 
-	if err := apiExt.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	return newSettings(config, options...)
+}
 
-	if err := metalLbOperatorV1Beta1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+// NewFromRESTConfig returns a *Settings built from a copy of the given rest config.
+func NewFromRESTConfig(config *rest.Config, options ...ConfigOption) (*Settings, error) {
+	glog.V(100).Infof("Loading kube client config from rest config")
 
-	if err := metalLbV1Beta1.AddToScheme(crScheme); err != nil {
-		return err
+	if config == nil {
+		return nil, fmt.Errorf("rest config cannot be nil")
 	}
 
-	if err := performanceV2.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	return newSettings(rest.CopyConfig(config), options...)
+}
 
-	if err := operatorV1.Install(crScheme); err != nil {
-		return err
-	}
+// NewFromToken returns a *Settings talking to the given API server URL with the given bearer token.
+// The API server certificate is verified against the system roots unless WithCAData or
+// WithInsecureSkipTLSVerify is passed.
+func NewFromToken(server, token string, options ...ConfigOption) (*Settings, error) {
+	glog.V(100).Infof("Loading kube client config for server %s with bearer token", server)
 
-	if err := olm2.AddToScheme(crScheme); err != nil {
-		return err
+	if server == "" {
+		return nil, fmt.Errorf("server cannot be empty")
 	}
 
-	if err := bmhv1alpha1.AddToScheme(crScheme); err != nil {
-		return err
+	if token == "" {
+		return nil, fmt.Errorf("token cannot be empty")
 	}
 
-	if err := hiveextV1Beta1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	return newSettings(&rest.Config{Host: server, BearerToken: token}, options...)
+}
 
-	if err := hiveV1.AddToScheme(crScheme); err != nil {
-		return err
+// newSettings applies the options to the given rest config and creates all clients of Settings for it.
+func newSettings(config *rest.Config, options ...ConfigOption) (*Settings, error) {
+	for _, option := range options {
+		if option != nil {
+			option(config)
+		}
 	}
 
-	if err := agentInstallV1Beta1.AddToScheme(crScheme); err != nil {
-		return err
+	// The typed clients below are created on their first use and only fail when no HTTP client can be built for the
+	// config, so check it here instead of panicking later.
+	if _, err := rest.HTTPClientFor(config); err != nil {
+		return nil, fmt.Errorf("invalid kube client config: %w", err)
 	}
 
-	if err := moduleV1Beta1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	clientSet := &Settings{}
+	clientSet.CoreV1Interface = coreV1Client.New(newLazyRESTClient(func() rest.Interface {
+		return coreV1Client.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.ConfigV1Interface = clientConfigV1.New(newLazyRESTClient(func() rest.Interface {
+		return clientConfigV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.MachineconfigurationV1Interface = clientMachineConfigV1.New(newLazyRESTClient(func() rest.Interface {
+		return clientMachineConfigV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.AppsV1Interface = appsV1Client.New(newLazyRESTClient(func() rest.Interface {
+		return appsV1Client.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.SriovnetworkV1Interface = clientSrIovV1.New(newLazyRESTClient(func() rest.Interface {
+		return clientSrIovV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.NetworkingV1Client = *networkV1Client.New(newLazyRESTClient(func() rest.Interface {
+		return networkV1Client.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.PtpV1Interface = ptpV1.New(newLazyRESTClient(func() rest.Interface {
+		return ptpV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.RbacV1Interface = rbacV1Client.New(newLazyRESTClient(func() rest.Interface {
+		return rbacV1Client.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.OperatorsV1alpha1Interface = olm.New(newLazyRESTClient(func() rest.Interface {
+		return olm.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.K8sCniCncfIoV1Interface = clientNetAttDefV1.New(newLazyRESTClient(func() rest.Interface {
+		return clientNetAttDefV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.Interface = lazyDynamicClient{lazyClient: newLazyClient(func() dynamic.Interface {
+		return dynamic.NewForConfigOrDie(config)
+	})}
+	clientSet.OperatorsV1Interface = olmv1.New(newLazyRESTClient(func() rest.Interface {
+		return olmv1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.PackageManifestInterface = clientPkgManifestV1.New(newLazyRESTClient(func() rest.Interface {
+		return clientPkgManifestV1.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.SecurityV1Interface = v1security.New(newLazyRESTClient(func() rest.Interface {
+		return v1security.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.ArgoprojV1alpha1Interface = argocdClient.New(newLazyRESTClient(func() rest.Interface {
+		return argocdClient.NewForConfigOrDie(config).RESTClient()
+	}))
+	clientSet.discoveryClient = discovery.NewDiscoveryClient(newLazyRESTClient(func() rest.Interface {
+		return discovery.NewDiscoveryClientForConfigOrDie(config).RESTClient()
+	}))
 
-	if err := nvidiagpuv1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	clientSet.Config = config
 
-	if err := nfdv1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	crScheme := runtime.NewScheme()
 
-	if err := pkgManifestV1.AddToScheme(crScheme); err != nil {
-		return err
+	if err := SetScheme(crScheme); err != nil {
+		return nil, fmt.Errorf("error to load apiClient scheme: %w", err)
 	}
 
-	if err := nmstatev1.AddToScheme(crScheme); err != nil {
-		return err
+	restMapper, err := apiutil.NewDynamicRESTMapper(config, apiutil.WithLazyDiscovery)
+	if err != nil {
+		return nil, fmt.Errorf("error to create apiClient rest mapper: %w", err)
 	}
 
-	if err := nmstateV1alpha1.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	client, err := runtimeClient.New(config, runtimeClient.Options{
+		Scheme: crScheme,
+		Mapper: restMapper,
+	})
 
-	if err := argocdOperatorv1alpha1.AddToScheme(crScheme); err != nil {
-		return err
+	if err != nil {
+		return nil, fmt.Errorf("error to create apiClient: %w", err)
 	}
 
-	if err := argocdScheme.AddToScheme(crScheme); err != nil {
-		return err
-	}
+	clientSet.Client = crdAwareClient{Client: client}
 
-	return nil
+	return clientSet, nil
 }
 
 // GetAPIClient implements the cluster.APIClientGetter interface.
@@ -0,0 +1,156 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	runtimeClient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CRDNotInstalledError is returned by the runtime client of Settings when the cluster does not serve the kind
+// of an object, usually because the operator providing its CRD is not installed. Builders report such objects
+// as not existing and their Pull functions return the CRDNotInstalledError.
+//
+// Only requests made through the runtime client are covered. Builders using the typed clientsets, e.g. the
+// MachineConfig, SriovNetworkNodePolicy, SriovNetwork and NetworkAttachmentDefinition builders, still get a plain
+// NotFound error from the API server when the CRD is missing.
+type CRDNotInstalledError struct {
+	GroupKind schema.GroupKind
+	Err       error
+}
+
+// Error returns the message of the error.
+func (crdError *CRDNotInstalledError) Error() string {
+	return fmt.Sprintf("CRD for %s is not installed: %v", crdError.GroupKind.String(), crdError.Err)
+}
+
+// Unwrap returns the underlying no match error.
+func (crdError *CRDNotInstalledError) Unwrap() error {
+	return crdError.Err
+}
+
+// IsCRDNotInstalled returns true when the error, or any error it wraps, is a CRDNotInstalledError.
+func IsCRDNotInstalled(err error) bool {
+	var crdError *CRDNotInstalledError
+
+	return errors.As(err, &crdError)
+}
+
+// crdAwareClient provides struct for a runtime client returning CRDNotInstalledError instead of no match errors.
+type crdAwareClient struct {
+	runtimeClient.Client
+}
+
+// Get retrieves the object for the given key.
+func (client crdAwareClient) Get(
+	ctx context.Context, key runtimeClient.ObjectKey, obj runtimeClient.Object, opts ...runtimeClient.GetOption) error {
+	return wrapNoMatchError(client.Client.Get(ctx, key, obj, opts...))
+}
+
+// List retrieves the list of objects matching the given options.
+func (client crdAwareClient) List(
+	ctx context.Context, list runtimeClient.ObjectList, opts ...runtimeClient.ListOption) error {
+	return wrapNoMatchError(client.Client.List(ctx, list, opts...))
+}
+
+// Create saves the given object.
+func (client crdAwareClient) Create(
+	ctx context.Context, obj runtimeClient.Object, opts ...runtimeClient.CreateOption) error {
+	return wrapNoMatchError(client.Client.Create(ctx, obj, opts...))
+}
+
+// Delete deletes the given object.
+func (client crdAwareClient) Delete(
+	ctx context.Context, obj runtimeClient.Object, opts ...runtimeClient.DeleteOption) error {
+	return wrapNoMatchError(client.Client.Delete(ctx, obj, opts...))
+}
+
+// Update updates the given object.
+func (client crdAwareClient) Update(
+	ctx context.Context, obj runtimeClient.Object, opts ...runtimeClient.UpdateOption) error {
+	return wrapNoMatchError(client.Client.Update(ctx, obj, opts...))
+}
+
+// Patch patches the given object.
+func (client crdAwareClient) Patch(ctx context.Context,
+	obj runtimeClient.Object, patch runtimeClient.Patch, opts ...runtimeClient.PatchOption) error {
+	return wrapNoMatchError(client.Client.Patch(ctx, obj, patch, opts...))
+}
+
+// DeleteAllOf deletes all objects of the type of the given object matching the given options.
+func (client crdAwareClient) DeleteAllOf(
+	ctx context.Context, obj runtimeClient.Object, opts ...runtimeClient.DeleteAllOfOption) error {
+	return wrapNoMatchError(client.Client.DeleteAllOf(ctx, obj, opts...))
+}
+
+// Status returns a client for the status subresource of objects.
+//
+//nolint:ireturn
+func (client crdAwareClient) Status() runtimeClient.SubResourceWriter {
+	return crdAwareSubResourceClient{SubResourceWriter: client.Client.Status()}
+}
+
+// SubResource returns a client for the named subresource of objects.
+//
+//nolint:ireturn
+func (client crdAwareClient) SubResource(subResource string) runtimeClient.SubResourceClient {
+	subResourceClient := client.Client.SubResource(subResource)
+
+	return crdAwareSubResourceClient{SubResourceReader: subResourceClient, SubResourceWriter: subResourceClient}
+}
+
+// crdAwareSubResourceClient provides struct for a subresource client returning CRDNotInstalledError instead of
+// no match errors. The reader is nil for the status writer.
+type crdAwareSubResourceClient struct {
+	runtimeClient.SubResourceReader
+	runtimeClient.SubResourceWriter
+}
+
+// Get retrieves the subresource of the given object.
+func (client crdAwareSubResourceClient) Get(ctx context.Context,
+	obj runtimeClient.Object, subResource runtimeClient.Object, opts ...runtimeClient.SubResourceGetOption) error {
+	return wrapNoMatchError(client.SubResourceReader.Get(ctx, obj, subResource, opts...))
+}
+
+// Create saves the subresource of the given object.
+func (client crdAwareSubResourceClient) Create(ctx context.Context,
+	obj runtimeClient.Object, subResource runtimeClient.Object, opts ...runtimeClient.SubResourceCreateOption) error {
+	return wrapNoMatchError(client.SubResourceWriter.Create(ctx, obj, subResource, opts...))
+}
+
+// Update updates the subresource of the given object.
+func (client crdAwareSubResourceClient) Update(
+	ctx context.Context, obj runtimeClient.Object, opts ...runtimeClient.SubResourceUpdateOption) error {
+	return wrapNoMatchError(client.SubResourceWriter.Update(ctx, obj, opts...))
+}
+
+// Patch patches the subresource of the given object.
+func (client crdAwareSubResourceClient) Patch(ctx context.Context,
+	obj runtimeClient.Object, patch runtimeClient.Patch, opts ...runtimeClient.SubResourcePatchOption) error {
+	return wrapNoMatchError(client.SubResourceWriter.Patch(ctx, obj, patch, opts...))
+}
+
+func wrapNoMatchError(err error) error {
+	if !meta.IsNoMatchError(err) {
+		return err
+	}
+
+	var kindError *meta.NoKindMatchError
+	if errors.As(err, &kindError) {
+		return &CRDNotInstalledError{GroupKind: kindError.GroupKind, Err: err}
+	}
+
+	var resourceError *meta.NoResourceMatchError
+	if errors.As(err, &resourceError) {
+		return &CRDNotInstalledError{
+			GroupKind: schema.GroupKind{
+				Group: resourceError.PartialResource.Group, Kind: resourceError.PartialResource.Resource},
+			Err: err,
+		}
+	}
+
+	return &CRDNotInstalledError{Err: err}
+}
@@ -0,0 +1,136 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/golang/glog"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+)
+
+const (
+	openShiftConfigGroup        = "config.openshift.io"
+	openShiftClusterVersionName = "version"
+)
+
+// IsGVKServed returns true when the cluster serves the given kind in the given group version.
+func (settings *Settings) IsGVKServed(gvk schema.GroupVersionKind) (bool, error) {
+	glog.V(100).Infof("Checking if %s is served by the cluster", gvk.String())
+
+	discoveryClient, err := settings.getDiscoveryClient()
+	if err != nil {
+		return false, err
+	}
+
+	resources, err := discoveryClient.ServerResourcesForGroupVersion(gvk.GroupVersion().String())
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return false, nil
+		}
+
+		return false, fmt.Errorf("failed to discover resources of %s: %w", gvk.GroupVersion().String(), err)
+	}
+
+	for _, resource := range resources.APIResources {
+		// Subresources such as status are reported with the kind of their parent resource.
+		if resource.Kind == gvk.Kind && !strings.Contains(resource.Name, "/") {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ServedVersions returns the versions of the given API group served by the cluster, the preferred version first.
+// The returned list is empty when the group is not served.
+func (settings *Settings) ServedVersions(group string) ([]string, error) {
+	glog.V(100).Infof("Collecting served versions of API group %s", group)
+
+	discoveryClient, err := settings.getDiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("failed to discover API groups: %w", err)
+	}
+
+	var versions []string
+
+	for _, apiGroup := range groups.Groups {
+		if apiGroup.Name != group {
+			continue
+		}
+
+		versions = append(versions, apiGroup.PreferredVersion.Version)
+
+		for _, version := range apiGroup.Versions {
+			if version.Version != apiGroup.PreferredVersion.Version {
+				versions = append(versions, version.Version)
+			}
+		}
+	}
+
+	return versions, nil
+}
+
+// IsOpenShift returns true when the cluster serves the OpenShift config API group.
+func (settings *Settings) IsOpenShift() (bool, error) {
+	versions, err := settings.ServedVersions(openShiftConfigGroup)
+	if err != nil {
+		return false, err
+	}
+
+	return len(versions) > 0, nil
+}
+
+// GetClusterVersion returns the desired OpenShift version of the cluster, or the Kubernetes version of
+// the API server when the cluster is not OpenShift.
+func (settings *Settings) GetClusterVersion() (string, error) {
+	isOpenShift, err := settings.IsOpenShift()
+	if err != nil {
+		return "", err
+	}
+
+	if isOpenShift {
+		glog.V(100).Infof("Collecting OpenShift cluster version")
+
+		clusterVersion, err := settings.ConfigV1Interface.ClusterVersions().Get(
+			context.TODO(), openShiftClusterVersionName, metaV1.GetOptions{})
+		if err != nil {
+			return "", fmt.Errorf("failed to get clusterversion %s: %w", openShiftClusterVersionName, err)
+		}
+
+		return clusterVersion.Status.Desired.Version, nil
+	}
+
+	glog.V(100).Infof("Collecting Kubernetes server version")
+
+	discoveryClient, err := settings.getDiscoveryClient()
+	if err != nil {
+		return "", err
+	}
+
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return "", fmt.Errorf("failed to get server version: %w", err)
+	}
+
+	return serverVersion.GitVersion, nil
+}
+
+//nolint:ireturn
+func (settings *Settings) getDiscoveryClient() (discovery.DiscoveryInterface, error) {
+	if settings == nil || settings.discoveryClient == nil {
+		glog.V(100).Infof("APIClient or its discovery client is nil")
+
+		return nil, fmt.Errorf("discovery client is not initialized")
+	}
+
+	return settings.discoveryClient, nil
+}
@@ -0,0 +1,97 @@
+package clients
+
+import (
+	"sync"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/util/flowcontrol"
+)
+
+// lazyClient provides struct for a client created on its first use and reused afterwards.
+type lazyClient[T any] struct {
+	once   sync.Once
+	create func() T
+	client T
+}
+
+func newLazyClient[T any](create func() T) *lazyClient[T] {
+	return &lazyClient[T]{create: create}
+}
+
+// get returns the client, creating it on the first call.
+//
+//nolint:ireturn
+func (lazy *lazyClient[T]) get() T {
+	lazy.once.Do(func() {
+		lazy.client = lazy.create()
+	})
+
+	return lazy.client
+}
+
+// lazyRESTClient provides struct for the REST client of a typed clientset, which is only built when the clientset
+// sends its first request. The typed clientsets of Settings are created on top of it with their New function.
+type lazyRESTClient struct {
+	*lazyClient[rest.Interface]
+}
+
+func newLazyRESTClient(create func() rest.Interface) lazyRESTClient {
+	return lazyRESTClient{lazyClient: newLazyClient(create)}
+}
+
+// GetRateLimiter returns the rate limiter of the REST client.
+//
+//nolint:ireturn
+func (client lazyRESTClient) GetRateLimiter() flowcontrol.RateLimiter {
+	return client.get().GetRateLimiter()
+}
+
+// Verb begins a request with the given verb.
+func (client lazyRESTClient) Verb(verb string) *rest.Request {
+	return client.get().Verb(verb)
+}
+
+// Post begins a POST request.
+func (client lazyRESTClient) Post() *rest.Request {
+	return client.get().Post()
+}
+
+// Put begins a PUT request.
+func (client lazyRESTClient) Put() *rest.Request {
+	return client.get().Put()
+}
+
+// Patch begins a PATCH request with the given patch type.
+func (client lazyRESTClient) Patch(patchType types.PatchType) *rest.Request {
+	return client.get().Patch(patchType)
+}
+
+// Get begins a GET request.
+func (client lazyRESTClient) Get() *rest.Request {
+	return client.get().Get()
+}
+
+// Delete begins a DELETE request.
+func (client lazyRESTClient) Delete() *rest.Request {
+	return client.get().Delete()
+}
+
+// APIVersion returns the group version the REST client talks to.
+func (client lazyRESTClient) APIVersion() schema.GroupVersion {
+	return client.get().APIVersion()
+}
+
+// lazyDynamicClient provides struct for a dynamic client created on its first use.
+type lazyDynamicClient struct {
+	*lazyClient[dynamic.Interface]
+}
+
+// Resource returns a client for the given resource.
+//
+//nolint:ireturn
+func (client lazyDynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return client.get().Resource(resource)
+}
@@ -0,0 +1,59 @@
+package clients
+
+import (
+	"time"
+
+	"k8s.io/client-go/rest"
+)
+
+// ConfigOption mutates the rest config used to build the clients of Settings.
+type ConfigOption func(config *rest.Config)
+
+// WithQPS sets the maximum queries per second and the burst allowed towards the API server.
+func WithQPS(qps float32, burst int) ConfigOption {
+	return func(config *rest.Config) {
+		config.QPS = qps
+		config.Burst = burst
+	}
+}
+
+// WithTimeout sets the timeout of every request sent to the API server.
+func WithTimeout(timeout time.Duration) ConfigOption {
+	return func(config *rest.Config) {
+		config.Timeout = timeout
+	}
+}
+
+// WithUserAgent sets the user agent sent with every request to the API server.
+func WithUserAgent(userAgent string) ConfigOption {
+	return func(config *rest.Config) {
+		config.UserAgent = userAgent
+	}
+}
+
+// WithImpersonation sends every request as the given user and groups.
+func WithImpersonation(userName string, groups ...string) ConfigOption {
+	return func(config *rest.Config) {
+		config.Impersonate = rest.ImpersonationConfig{
+			UserName: userName,
+			Groups:   groups,
+		}
+	}
+}
+
+// WithCAData sets the PEM encoded certificate authority used to verify the API server certificate.
+func WithCAData(caData []byte) ConfigOption {
+	return func(config *rest.Config) {
+		config.TLSClientConfig.CAData = caData
+		config.TLSClientConfig.Insecure = false
+	}
+}
+
+// WithInsecureSkipTLSVerify disables the verification of the API server certificate.
+func WithInsecureSkipTLSVerify() ConfigOption {
+	return func(config *rest.Config) {
+		config.TLSClientConfig.Insecure = true
+		config.TLSClientConfig.CAData = nil
+		config.TLSClientConfig.CAFile = ""
+	}
+}
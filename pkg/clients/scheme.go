@@ -0,0 +1,108 @@
+package clients
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+)
+
+// SchemeAttacher adds the types of an API group to a scheme, usually the AddToScheme function of the group.
+type SchemeAttacher func(*runtime.Scheme) error
+
+var (
+	schemeAttachersMutex sync.Mutex
+	// schemeAttachers holds the API groups added to the scheme of every Settings. Only the built-in kubernetes
+	// groups are registered here, the packages of this module attach the CRD groups they manage with AttachScheme.
+	schemeAttachers = []SchemeAttacher{scheme.AddToScheme}
+	// attachSchemeMutex serializes AttachScheme calls since runtime.Scheme is not safe for concurrent writes.
+	attachSchemeMutex sync.Mutex
+)
+
+// RegisterScheme registers attachers applied to the scheme of every Settings created afterwards, in addition to
+// the built-in API groups. Downstream users call it to make their own CRD types available to the runtime client,
+// including the groups this module only reads through typed clientsets, e.g. MachineConfig, NAD and OLM, and the
+// groups without a package of their own, e.g. Argo CD and apiextensions.
+func RegisterScheme(attachers ...SchemeAttacher) {
+	schemeAttachersMutex.Lock()
+	defer schemeAttachersMutex.Unlock()
+
+	for _, attacher := range attachers {
+		if attacher != nil {
+			schemeAttachers = append(schemeAttachers, attacher)
+		}
+	}
+}
+
+// SetScheme adds the types of all registered API groups to the given scheme.
+func SetScheme(crScheme *runtime.Scheme) error {
+	schemeAttachersMutex.Lock()
+	attachers := make([]SchemeAttacher, len(schemeAttachers))
+	copy(attachers, schemeAttachers)
+	schemeAttachersMutex.Unlock()
+
+	for _, attacher := range attachers {
+		if err := attacher(crScheme); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AttachScheme adds the types of the given attachers to the scheme of the runtime client of these settings only.
+// Attachers whose types are all known to the scheme already are skipped, so packages call it every time one of
+// their builders is created. The first call for an API group must not race with requests made through the runtime
+// client.
+func (settings *Settings) AttachScheme(attachers ...SchemeAttacher) error {
+	if settings == nil || settings.Client == nil {
+		glog.V(100).Infof("APIClient or its runtime client is nil")
+
+		return fmt.Errorf("cannot attach scheme: runtime client is not initialized")
+	}
+
+	attachSchemeMutex.Lock()
+	defer attachSchemeMutex.Unlock()
+
+	clientScheme := settings.Client.Scheme()
+
+	for _, attacher := range attachers {
+		if attacher == nil {
+			continue
+		}
+
+		attached, err := isSchemeAttached(clientScheme, attacher)
+		if err != nil {
+			return fmt.Errorf("failed to attach scheme to apiClient: %w", err)
+		}
+
+		if attached {
+			continue
+		}
+
+		if err := attacher(clientScheme); err != nil {
+			return fmt.Errorf("failed to attach scheme to apiClient: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// isSchemeAttached reports whether the given scheme recognizes every type the attacher adds.
+func isSchemeAttached(crScheme *runtime.Scheme, attacher SchemeAttacher) (bool, error) {
+	attacherScheme := runtime.NewScheme()
+
+	if err := attacher(attacherScheme); err != nil {
+		return false, err
+	}
+
+	for gvk := range attacherScheme.AllKnownTypes() {
+		if !crScheme.Recognizes(gvk) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
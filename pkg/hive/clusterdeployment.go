@@ -3,6 +3,7 @@ package hive
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
@@ -13,6 +14,7 @@ import (
 	coreV1 "k8s.io/api/core/v1"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
@@ -68,6 +70,12 @@ func NewABMClusterDeploymentBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterdeployment scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add clusterdeployment scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the clusterdeployment is empty")
 
@@ -183,6 +191,12 @@ func ListClusterDeploymentsInAllNamespaces(
 	options goclient.ListOption) ([]*ClusterDeploymentBuilder, error) {
 	glog.V(100).Infof("Listing all clusterdeployments with the options %v", options)
 
+	if err := apiClient.AttachScheme(hiveV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterdeployment scheme to client schemes")
+
+		return nil, err
+	}
+
 	clusterDeployments := new(hiveV1.ClusterDeploymentList)
 	err := apiClient.List(context.TODO(), clusterDeployments, options)
 
@@ -222,6 +236,12 @@ func PullClusterDeployment(apiClient *clients.Settings, name, nsname string) (*C
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterdeployment scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the clusterdeployment is empty")
 
@@ -234,11 +254,17 @@ func PullClusterDeployment(apiClient *clients.Settings, name, nsname string) (*C
 		builder.errorMsg = "clusterdeployment 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("clusterdeployment object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -351,6 +377,100 @@ func (builder *ClusterDeploymentBuilder) Delete() (*ClusterDeploymentBuilder, er
 	return builder, nil
 }
 
+// WaitForInstalled waits the defined timeout for the clusterdeployment to be reported as installed.
+// It stops early when the clusterdeployment reports that provisioning failed.
+func (builder *ClusterDeploymentBuilder) WaitForInstalled(timeout time.Duration) (*ClusterDeploymentBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Waiting for clusterdeployment %s in namespace %s to be installed",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	// Polls every 10 seconds to determine if clusterdeployment is installed.
+	err := wait.PollImmediate(10*time.Second, timeout, func() (bool, error) {
+		var err error
+		builder.Object, err = builder.Get()
+
+		if err != nil {
+			return false, nil
+		}
+
+		for _, condition := range builder.Object.Status.Conditions {
+			if condition.Type == hiveV1.ProvisionFailedCondition && condition.Status == coreV1.ConditionTrue {
+				return false, fmt.Errorf("clusterdeployment %s provisioning failed: %s", builder.Definition.Name,
+					condition.Message)
+			}
+		}
+
+		return builder.Object.Spec.Installed, nil
+	})
+
+	if err == nil {
+		return builder, nil
+	}
+
+	return nil, err
+}
+
+// GetAdminKubeconfig returns the admin kubeconfig of the installed cluster.
+func (builder *ClusterDeploymentBuilder) GetAdminKubeconfig() ([]byte, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting admin kubeconfig of clusterdeployment %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	clusterMetadata, err := builder.getClusterMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	return builder.getSecretValue(clusterMetadata.AdminKubeconfigSecretRef.Name, "kubeconfig")
+}
+
+// GetAdminPassword returns the kubeadmin username and password of the installed cluster.
+func (builder *ClusterDeploymentBuilder) GetAdminPassword() (string, string, error) {
+	if valid, err := builder.validate(); !valid {
+		return "", "", err
+	}
+
+	glog.V(100).Infof("Getting admin password of clusterdeployment %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	clusterMetadata, err := builder.getClusterMetadata()
+	if err != nil {
+		return "", "", err
+	}
+
+	if clusterMetadata.AdminPasswordSecretRef == nil {
+		return "", "", fmt.Errorf("clusterdeployment %s has no admin password secret", builder.Definition.Name)
+	}
+
+	username, err := builder.getSecretValue(clusterMetadata.AdminPasswordSecretRef.Name, "username")
+	if err != nil {
+		return "", "", err
+	}
+
+	password, err := builder.getSecretValue(clusterMetadata.AdminPasswordSecretRef.Name, "password")
+	if err != nil {
+		return "", "", err
+	}
+
+	return string(username), string(password), nil
+}
+
+// GetSpokeAPIClient returns a client for the installed cluster built from its admin kubeconfig.
+func (builder *ClusterDeploymentBuilder) GetSpokeAPIClient() (*clients.Settings, error) {
+	kubeconfig, err := builder.GetAdminKubeconfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return clients.NewFromKubeconfig(kubeconfig)
+}
+
 // Exists checks if the defined clusterdeployment has already been created.
 func (builder *ClusterDeploymentBuilder) Exists() bool {
 	if valid, _ := builder.validate(); !valid {
@@ -363,7 +483,40 @@ func (builder *ClusterDeploymentBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
+}
+
+// getClusterMetadata returns the metadata hive populates once the cluster is installed.
+func (builder *ClusterDeploymentBuilder) getClusterMetadata() (*hiveV1.ClusterMetadata, error) {
+	var err error
+	builder.Object, err = builder.Get()
+
+	if err != nil {
+		return nil, err
+	}
+
+	if builder.Object.Spec.ClusterMetadata == nil {
+		return nil, fmt.Errorf("clusterdeployment %s has no cluster metadata, it is not installed yet",
+			builder.Definition.Name)
+	}
+
+	return builder.Object.Spec.ClusterMetadata, nil
+}
+
+// getSecretValue returns the value of key in the given secret from the clusterdeployment namespace.
+func (builder *ClusterDeploymentBuilder) getSecretValue(secretName, key string) ([]byte, error) {
+	secret, err := builder.apiClient.Secrets(builder.Definition.Namespace).Get(
+		context.TODO(), secretName, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := secret.Data[key]
+	if !ok {
+		return nil, fmt.Errorf("secret %s in namespace %s has no %s key", secretName, builder.Definition.Namespace, key)
+	}
+
+	return value, nil
 }
 
 // validate will check that the builder and builder definition are properly initialized before
@@ -43,6 +43,12 @@ func NewClusterImageSetBuilder(apiClient *clients.Settings, name, releaseImage s
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterimageset scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add clusterimageset scheme to client schemes: %v", err)
+	}
+
 	if apiClient == nil {
 		glog.V(100).Infof("The apiClient is nil")
 
@@ -127,15 +133,27 @@ func PullClusterImageSet(apiClient *clients.Settings, name string) (*ClusterImag
 		},
 	}
 
+	if err := apiClient.AttachScheme(hiveV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterimageset scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		builder.errorMsg = "clusterimageset 'name' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("clusterimageset object %s doesn't exist", name)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -249,7 +267,7 @@ func (builder *ClusterImageSetBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // validate will check that the builder and builder definition are properly initialized before
@@ -46,6 +46,12 @@ func NewModuleBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(moduleV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add module scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add module scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the Module is empty")
 
@@ -249,6 +255,12 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*ModuleBuilder, err
 		},
 	}
 
+	if err := apiClient.AttachScheme(moduleV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add module scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the module is empty")
 
@@ -261,11 +273,17 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*ModuleBuilder, err
 		builder.errorMsg = "module 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("module object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -300,7 +318,7 @@ func (builder *ModuleBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Delete removes the module.
@@ -46,6 +46,12 @@ func NewIPAddressPoolBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add IPAddressPool scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add IPAddressPool scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the IPAddressPool is empty")
 
@@ -107,7 +113,7 @@ func (builder *IPAddressPoolBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // PullAddressPool pulls existing addresspool from cluster.
@@ -124,6 +130,12 @@ func PullAddressPool(apiClient *clients.Settings, name, nsname string) (*IPAddre
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add IPAddressPool scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the addresspool is empty")
 
@@ -136,11 +148,17 @@ func PullAddressPool(apiClient *clients.Settings, name, nsname string) (*IPAddre
 		builder.errorMsg = "addresspool 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("addresspool object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
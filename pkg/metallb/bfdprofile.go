@@ -44,6 +44,12 @@ func NewBFDBuilder(apiClient *clients.Settings, name, nsname string) *BFDBuilder
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bfdprofile scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add bfdprofile scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the BFDProfile is empty")
 
@@ -99,7 +105,7 @@ func (builder *BFDBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // PullBFDProfile pulls existing bfdprofile from cluster.
@@ -116,6 +122,12 @@ func PullBFDProfile(apiClient *clients.Settings, name, nsname string) (*BFDBuild
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bfdprofile scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the bfdprofile is empty")
 
@@ -128,11 +140,17 @@ func PullBFDProfile(apiClient *clients.Settings, name, nsname string) (*BFDBuild
 		builder.errorMsg = "bfdprofile 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("bfdprofile object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
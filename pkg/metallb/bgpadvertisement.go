@@ -42,6 +42,12 @@ func NewBGPAdvertisementBuilder(apiClient *clients.Settings, name, nsname string
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bgpadvertisement scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add bgpadvertisement scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the BGPAdvertisement is empty")
 
@@ -70,7 +76,7 @@ func (builder *BGPAdvertisementBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns BGPAdvertisement object if found.
@@ -114,6 +120,12 @@ func PullBGPAdvertisement(apiClient *clients.Settings, name, nsname string) (*BG
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bgpadvertisement scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the bgpadvertisement is empty")
 
@@ -126,11 +138,17 @@ func PullBGPAdvertisement(apiClient *clients.Settings, name, nsname string) (*BG
 		builder.errorMsg = "bgpadvertisement 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("bgpadvertisement object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -49,6 +49,12 @@ func NewBPGPeerBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bgppeer scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add bgppeer scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the BGPPeer is empty")
 
@@ -110,7 +116,7 @@ func (builder *BGPPeerBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // PullBGPPeer pulls existing bgppeer from cluster.
@@ -127,6 +133,12 @@ func PullBGPPeer(apiClient *clients.Settings, name, nsname string) (*BGPPeerBuil
 		},
 	}
 
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add bgppeer scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the bgppeer is empty")
 
@@ -139,11 +151,17 @@ func PullBGPPeer(apiClient *clients.Settings, name, nsname string) (*BGPPeerBuil
 		builder.errorMsg = "bgppeer 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("bgppeer object %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -0,0 +1,376 @@
+package metallb
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	metalLbV1Beta "go.universe.tf/metallb/api/v1beta1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// CommunityBuilder provides struct for the Community object containing connection to
+// the cluster and the Community definitions. Community aliases can be referenced by name
+// in BGPAdvertisement communities.
+type CommunityBuilder struct {
+	Definition *metalLbV1Beta.Community
+	Object     *metalLbV1Beta.Community
+	apiClient  *clients.Settings
+	errorMsg   string
+}
+
+// CommunityAdditionalOptions additional options for Community object.
+type CommunityAdditionalOptions func(builder *CommunityBuilder) (*CommunityBuilder, error)
+
+// NewCommunityBuilder creates a new instance of CommunityBuilder.
+func NewCommunityBuilder(apiClient *clients.Settings, name, nsname string) *CommunityBuilder {
+	glog.V(100).Infof(
+		"Initializing new Community structure with the following params: %s, %s",
+		name, nsname)
+
+	builder := CommunityBuilder{
+		apiClient: apiClient,
+		Definition: &metalLbV1Beta.Community{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			}, Spec: metalLbV1Beta.CommunitySpec{},
+		},
+	}
+
+	if err := apiClient.AttachScheme(metalLbV1Beta.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add community scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add community scheme to client schemes: %v", err)
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the Community is empty")
+
+		builder.errorMsg = "Community 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the Community is empty")
+
+		builder.errorMsg = "Community 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// Exists checks whether the given Community exists.
+func (builder *CommunityBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof(
+		"Checking if Community %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
+}
+
+// Get returns Community object if found.
+func (builder *CommunityBuilder) Get() (*metalLbV1Beta.Community, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof(
+		"Collecting Community object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	metalLb := &metalLbV1Beta.Community{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, metalLb)
+
+	if err != nil {
+		glog.V(100).Infof(
+			"Community object %s doesn't exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return nil, err
+	}
+
+	return metalLb, err
+}
+
+// PullCommunity pulls existing community from cluster.
+func PullCommunity(apiClient *clients.Settings, name, nsname string) (*CommunityBuilder, error) {
+	glog.V(100).Infof("Pulling existing community name %s under namespace %s from cluster", name, nsname)
+
+	builder := CommunityBuilder{
+		apiClient: apiClient,
+		Definition: &metalLbV1Beta.Community{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(metalLbV1Beta.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add community scheme to client schemes")
+
+		return nil, err
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the community is empty")
+
+		builder.errorMsg = "community 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the community is empty")
+
+		builder.errorMsg = "community 'namespace' cannot be empty"
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("community object %s doesn't exist in namespace %s", name, nsname)
+	}
+
+	builder.Object = object
+	builder.Definition = object
+
+	return &builder, nil
+}
+
+// Create makes a Community in the cluster and stores the created object in struct.
+func (builder *CommunityBuilder) Create() (*CommunityBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the Community %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	var err error
+	if !builder.Exists() {
+		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+		if err == nil {
+			builder.Object = builder.Definition
+		}
+	}
+
+	return builder, err
+}
+
+// Delete removes Community object from a cluster.
+func (builder *CommunityBuilder) Delete() (*CommunityBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Deleting the Community object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("Community cannot be deleted because it does not exist")
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+
+	if err != nil {
+		return builder, fmt.Errorf("can not delete Community: %w", err)
+	}
+
+	builder.Object = nil
+
+	return builder, nil
+}
+
+// Update renovates the existing Community object with the Community definition in builder.
+func (builder *CommunityBuilder) Update(force bool) (*CommunityBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the Community object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace,
+	)
+
+	if !builder.Exists() {
+		glog.V(100).Infof(
+			"Failed to update the Community object %s in namespace %s. "+
+				"Resource doesn't exist",
+			builder.Definition.Name, builder.Definition.Namespace,
+		)
+
+		return nil, fmt.Errorf("failed to update Community, resource doesn't exist")
+	}
+
+	builder.Object.Spec = builder.Definition.Spec
+	err := builder.apiClient.Update(context.TODO(), builder.Object)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the Community object %s in namespace %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name, builder.Definition.Namespace,
+			)
+
+			builder, err := builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the Community object %s in namespace %s, "+
+						"due to error in delete function",
+					builder.Definition.Name, builder.Definition.Namespace,
+				)
+
+				return nil, err
+			}
+
+			return builder.Create()
+		}
+	}
+
+	return builder, err
+}
+
+// WithCommunityAlias adds a named BGP community to the Community. The value is either a standard community in
+// the <0-65535>:<0-65535> format or a large community in the large:<uint32>:<uint32>:<uint32> format.
+func (builder *CommunityBuilder) WithCommunityAlias(name, value string) *CommunityBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof(
+		"Creating Community %s in namespace %s with alias %s: %s",
+		builder.Definition.Name, builder.Definition.Namespace, name, value)
+
+	if name == "" {
+		builder.errorMsg = "error: community alias name is empty"
+	}
+
+	if !isValidCommunity(value) {
+		builder.errorMsg = fmt.Sprintf("error: community value %s is invalid", value)
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	for index, community := range builder.Definition.Spec.Communities {
+		if community.Name == name {
+			builder.Definition.Spec.Communities[index].Value = value
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Communities = append(
+		builder.Definition.Spec.Communities, metalLbV1Beta.CommunityAlias{Name: name, Value: value})
+
+	return builder
+}
+
+// WithOptions creates Community with generic mutation options.
+func (builder *CommunityBuilder) WithOptions(
+	options ...CommunityAdditionalOptions) *CommunityBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Community additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// GetCommunityGVR returns community's GroupVersionResource, which could be used for Clean function.
+func GetCommunityGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group: "metallb.io", Version: "v1beta1", Resource: "communities",
+	}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *CommunityBuilder) validate() (bool, error) {
+	resourceCRD := "Community"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
+
+// isValidCommunity checks that the value is a valid standard or large BGP community.
+func isValidCommunity(value string) bool {
+	fields := strings.Split(value, ":")
+	bitSize := 16
+
+	if len(fields) == 4 && fields[0] == "large" {
+		fields = fields[1:]
+		bitSize = 32
+	} else if len(fields) != 2 {
+		return false
+	}
+
+	for _, field := range fields {
+		if _, err := strconv.ParseUint(field, 10, bitSize); err != nil {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,323 @@
+package metallb
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	speakerPodLabelSelector = "component=speaker"
+	frrContainerName        = "frr"
+	bgpStateEstablished     = "Established"
+	bfdStatusUp             = "up"
+	// bgpLocalPeerID is reported by FRR as the peer of locally originated routes.
+	bgpLocalPeerID = "(unspec)"
+)
+
+// BGPNeighbor provides struct for the BGP neighbor state reported by 'show bgp neighbor json'.
+type BGPNeighbor struct {
+	Address           string                          `json:"-"`
+	RemoteAS          int                             `json:"remoteAs"`
+	LocalAS           int                             `json:"localAs"`
+	RemoteRouterID    string                          `json:"remoteRouterId"`
+	LocalRouterID     string                          `json:"localRouterId"`
+	State             string                          `json:"bgpState"`
+	UpTimeMsec        int64                           `json:"bgpTimerUpMsec"`
+	BFDInfo           *BGPNeighborBFDInfo             `json:"peerBfdInfo,omitempty"`
+	AddressFamilyInfo map[string]BGPAddressFamilyInfo `json:"addressFamilyInfo,omitempty"`
+}
+
+// BGPNeighborBFDInfo provides struct for the BFD settings and status of a BGP neighbor.
+type BGPNeighborBFDInfo struct {
+	Type             string `json:"type"`
+	DetectMultiplier int    `json:"detectMultiplier"`
+	RxMinInterval    int    `json:"rxMinInterval"`
+	TxMinInterval    int    `json:"txMinInterval"`
+	Status           string `json:"status"`
+}
+
+// BGPAddressFamilyInfo provides struct for the prefix counters of a BGP neighbor address family.
+type BGPAddressFamilyInfo struct {
+	AcceptedPrefixCounter int `json:"acceptedPrefixCounter"`
+	SentPrefixCounter     int `json:"sentPrefixCounter"`
+}
+
+// BFDPeer provides struct for the BFD session state reported by 'show bfd peers json'.
+type BFDPeer struct {
+	Peer                  string `json:"peer"`
+	Local                 string `json:"local"`
+	Interface             string `json:"interface"`
+	Multihop              bool   `json:"multihop"`
+	Status                string `json:"status"`
+	Uptime                int64  `json:"uptime"`
+	Diagnostic            string `json:"diagnostic"`
+	RemoteDiagnostic      string `json:"remote-diagnostic"`
+	DetectMultiplier      int    `json:"detect-multiplier"`
+	ReceiveInterval       int    `json:"receive-interval"`
+	TransmitInterval      int    `json:"transmit-interval"`
+	EchoInterval          int    `json:"echo-interval"`
+	RemoteReceiveInterval int    `json:"remote-receive-interval"`
+}
+
+// BGPRoute provides struct for a single path of a prefix reported by 'show ip bgp json'
+// or 'show bgp ipv6 unicast json'.
+type BGPRoute struct {
+	Prefix   string            `json:"network"`
+	Valid    bool              `json:"valid"`
+	BestPath bool              `json:"bestpath"`
+	PathFrom string            `json:"pathFrom"`
+	PeerID   string            `json:"peerId"`
+	Path     string            `json:"path"`
+	Origin   string            `json:"origin"`
+	Metric   int               `json:"metric"`
+	Weight   int               `json:"weight"`
+	NextHops []BGPRouteNextHop `json:"nexthops"`
+}
+
+// BGPRouteNextHop provides struct for the next hop of a BGP route.
+type BGPRouteNextHop struct {
+	IP   string `json:"ip"`
+	AFI  string `json:"afi"`
+	Used bool   `json:"used"`
+}
+
+// BGPRoutes provides struct for the BGP table of a speaker keyed by prefix.
+type BGPRoutes struct {
+	RouterID string                `json:"routerId"`
+	LocalAS  int                   `json:"localAS"`
+	Routes   map[string][]BGPRoute `json:"routes"`
+}
+
+// ListSpeakerPods returns the MetalLB speaker pods running in the given namespace.
+func ListSpeakerPods(apiClient *clients.Settings, nsname string) ([]*pod.Builder, error) {
+	glog.V(100).Infof("Listing MetalLB speaker pods in namespace %s", nsname)
+
+	return pod.List(apiClient, nsname, metaV1.ListOptions{LabelSelector: speakerPodLabelSelector})
+}
+
+// GetSpeakerPod returns the MetalLB speaker pod running on the given node.
+func GetSpeakerPod(apiClient *clients.Settings, nsname, nodeName string) (*pod.Builder, error) {
+	glog.V(100).Infof("Collecting MetalLB speaker pod on node %s in namespace %s", nodeName, nsname)
+
+	if nodeName == "" {
+		return nil, fmt.Errorf("failed to get speaker pod, 'nodeName' parameter is empty")
+	}
+
+	speakerPods, err := pod.List(apiClient, nsname, metaV1.ListOptions{
+		LabelSelector: speakerPodLabelSelector,
+		FieldSelector: fmt.Sprintf("spec.nodeName=%s", nodeName),
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(speakerPods) != 1 {
+		return nil, fmt.Errorf("expected one speaker pod on node %s in namespace %s, found %d",
+			nodeName, nsname, len(speakerPods))
+	}
+
+	return speakerPods[0], nil
+}
+
+// GetBGPNeighbors returns the BGP neighbors of the given speaker pod keyed by neighbor address.
+func GetBGPNeighbors(speakerPod *pod.Builder) (map[string]BGPNeighbor, error) {
+	output, err := runVtyshCommand(speakerPod, "show bgp neighbor json")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBGPNeighbors(output)
+}
+
+// GetBFDPeers returns the BFD peers of the given speaker pod keyed by peer address.
+func GetBFDPeers(speakerPod *pod.Builder) (map[string]BFDPeer, error) {
+	output, err := runVtyshCommand(speakerPod, "show bfd peers json")
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBFDPeers(output)
+}
+
+// GetBGPRoutes returns the BGP table of the given address family of the given speaker pod.
+func GetBGPRoutes(speakerPod *pod.Builder, ipFamily coreV1.IPFamily) (*BGPRoutes, error) {
+	var command string
+
+	switch ipFamily {
+	case coreV1.IPv4Protocol:
+		command = "show ip bgp json"
+	case coreV1.IPv6Protocol:
+		command = "show bgp ipv6 unicast json"
+	default:
+		return nil, fmt.Errorf("unsupported ip family %s, allowed families are %s and %s",
+			ipFamily, coreV1.IPv4Protocol, coreV1.IPv6Protocol)
+	}
+
+	output, err := runVtyshCommand(speakerPod, command)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseBGPRoutes(output)
+}
+
+// ParseBGPNeighbors parses the output of 'show bgp neighbor json'.
+func ParseBGPNeighbors(output string) (map[string]BGPNeighbor, error) {
+	neighbors := make(map[string]BGPNeighbor)
+
+	if err := json.Unmarshal([]byte(output), &neighbors); err != nil {
+		return nil, fmt.Errorf("failed to parse bgp neighbors: %w", err)
+	}
+
+	for address, neighbor := range neighbors {
+		neighbor.Address = address
+		neighbors[address] = neighbor
+	}
+
+	return neighbors, nil
+}
+
+// ParseBFDPeers parses the output of 'show bfd peers json'.
+func ParseBFDPeers(output string) (map[string]BFDPeer, error) {
+	var peerList []BFDPeer
+
+	if err := json.Unmarshal([]byte(output), &peerList); err != nil {
+		return nil, fmt.Errorf("failed to parse bfd peers: %w", err)
+	}
+
+	peers := make(map[string]BFDPeer)
+
+	for _, peer := range peerList {
+		peers[peer.Peer] = peer
+	}
+
+	return peers, nil
+}
+
+// ParseBGPRoutes parses the output of 'show ip bgp json' or 'show bgp ipv6 unicast json'.
+func ParseBGPRoutes(output string) (*BGPRoutes, error) {
+	routes := &BGPRoutes{}
+
+	if err := json.Unmarshal([]byte(output), routes); err != nil {
+		return nil, fmt.Errorf("failed to parse bgp routes: %w", err)
+	}
+
+	return routes, nil
+}
+
+// Advertised returns the prefixes originated by the speaker itself.
+func (routes *BGPRoutes) Advertised() []string {
+	return routes.prefixes(func(route BGPRoute) bool {
+		return route.PeerID == bgpLocalPeerID
+	})
+}
+
+// ReceivedFrom returns the valid prefixes learned from the given peer.
+func (routes *BGPRoutes) ReceivedFrom(peerAddress string) []string {
+	return routes.prefixes(func(route BGPRoute) bool {
+		return route.Valid && route.PeerID == peerAddress
+	})
+}
+
+// WaitUntilBGPPeerEstablished waits for the duration of the defined timeout or until
+// the BGP session with the given peer is established on all speaker pods in the namespace.
+func WaitUntilBGPPeerEstablished(
+	apiClient *clients.Settings, nsname, peerAddress string, timeout time.Duration) error {
+	glog.V(100).Infof("Waiting until BGP peer %s is established on all speakers in namespace %s",
+		peerAddress, nsname)
+
+	return waitUntilAllSpeakers(apiClient, nsname, timeout, func(speakerPod *pod.Builder) bool {
+		neighbors, err := GetBGPNeighbors(speakerPod)
+		if err != nil {
+			glog.V(100).Infof("Failed to get BGP neighbors from pod %s due to %v", speakerPod.Object.Name, err)
+
+			return false
+		}
+
+		return neighbors[peerAddress].State == bgpStateEstablished
+	})
+}
+
+// WaitUntilBFDPeerUp waits for the duration of the defined timeout or until
+// the BFD session with the given peer is up on all speaker pods in the namespace.
+func WaitUntilBFDPeerUp(apiClient *clients.Settings, nsname, peerAddress string, timeout time.Duration) error {
+	glog.V(100).Infof("Waiting until BFD peer %s is up on all speakers in namespace %s", peerAddress, nsname)
+
+	return waitUntilAllSpeakers(apiClient, nsname, timeout, func(speakerPod *pod.Builder) bool {
+		peers, err := GetBFDPeers(speakerPod)
+		if err != nil {
+			glog.V(100).Infof("Failed to get BFD peers from pod %s due to %v", speakerPod.Object.Name, err)
+
+			return false
+		}
+
+		return peers[peerAddress].Status == bfdStatusUp
+	})
+}
+
+func waitUntilAllSpeakers(
+	apiClient *clients.Settings, nsname string, timeout time.Duration, condition func(*pod.Builder) bool) error {
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		speakerPods, err := ListSpeakerPods(apiClient, nsname)
+		if err != nil {
+			glog.V(100).Infof("Failed to list speaker pods in namespace %s due to %v", nsname, err)
+
+			return false, nil
+		}
+
+		if len(speakerPods) == 0 {
+			return false, nil
+		}
+
+		for _, speakerPod := range speakerPods {
+			if !condition(speakerPod) {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+func runVtyshCommand(speakerPod *pod.Builder, command string) (string, error) {
+	if speakerPod == nil || speakerPod.Object == nil {
+		return "", fmt.Errorf("cannot run vtysh command: speaker pod is undefined")
+	}
+
+	glog.V(100).Infof("Running vtysh command %q in pod %s", command, speakerPod.Object.Name)
+
+	output, err := speakerPod.ExecCommand([]string{"vtysh", "-c", command}, frrContainerName)
+	if err != nil {
+		return "", fmt.Errorf("failed to run vtysh command %q in pod %s: %w", command, speakerPod.Object.Name, err)
+	}
+
+	return output.String(), nil
+}
+
+func (routes *BGPRoutes) prefixes(match func(BGPRoute) bool) []string {
+	var prefixes []string
+
+	for prefix, paths := range routes.Routes {
+		for _, path := range paths {
+			if match(path) {
+				prefixes = append(prefixes, prefix)
+
+				break
+			}
+		}
+	}
+
+	sort.Strings(prefixes)
+
+	return prefixes
+}
@@ -3,18 +3,29 @@ package metallb
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/golang/glog"
 	"github.com/metallb/metallb-operator/api/v1beta1"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/daemonset"
+	"github.com/openshift-kni/eco-goinfra/pkg/deployment"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 	k8serrors "k8s.io/apimachinery/pkg/api/errors"
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
+const (
+	speakerDaemonSetName     = "speaker"
+	controllerDeploymentName = "controller"
+	// readinessCheckTimeout is how long a single readiness check of the speaker or controller may take.
+	readinessCheckTimeout = time.Second
+)
+
 // Builder provides struct for the MetalLb object containing connection to
 // the cluster and the MetalLb definitions.
 type Builder struct {
@@ -45,6 +56,12 @@ func NewBuilder(apiClient *clients.Settings, name, nsname string, label map[stri
 		},
 	}
 
+	if err := apiClient.AttachScheme(v1beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add metallb scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add metallb scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the metallb is empty")
 
@@ -75,6 +92,12 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		},
 	}
 
+	if err := apiClient.AttachScheme(v1beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add metallb scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the metallb is empty")
 
@@ -87,11 +110,17 @@ func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 		builder.errorMsg = "metallb 'nsname' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("metallb oject %s doesn't exist in namespace %s", name, nsname)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -113,7 +142,7 @@ func (builder *Builder) Exists() bool {
 		glog.V(100).Infof("Failed to collect MetalLb object due to %s", err.Error())
 	}
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns MetalLb object if found.
@@ -232,6 +261,67 @@ func (builder *Builder) Update(force bool) (*Builder, error) {
 	return builder, err
 }
 
+// CreateAndWaitUntilReady creates a MetalLb in the cluster and waits until the speaker and controller are ready.
+func (builder *Builder) CreateAndWaitUntilReady(timeout time.Duration) (*Builder, error) {
+	builder, err := builder.Create()
+	if err != nil {
+		return builder, err
+	}
+
+	return builder, builder.WaitUntilReady(timeout)
+}
+
+// UpdateAndWaitUntilReady updates the MetalLb object and waits until the speaker and controller are ready.
+func (builder *Builder) UpdateAndWaitUntilReady(force bool, timeout time.Duration) (*Builder, error) {
+	builder, err := builder.Update(force)
+	if err != nil {
+		return builder, err
+	}
+
+	return builder, builder.WaitUntilReady(timeout)
+}
+
+// WaitUntilReady waits for the duration of the defined timeout or until the speaker DaemonSet
+// reflects the SpeakerNodeSelector with all its pods ready and the controller Deployment is ready.
+func (builder *Builder) WaitUntilReady(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until metallb %s in namespace %s is ready",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		speaker, err := daemonset.Pull(builder.apiClient, speakerDaemonSetName, builder.Definition.Namespace)
+		if err != nil {
+			glog.V(100).Infof("Failed to get DaemonSet %s due to %v", speakerDaemonSetName, err)
+
+			return false, nil
+		}
+
+		for key, value := range builder.Definition.Spec.SpeakerNodeSelector {
+			if speaker.Object.Spec.Template.Spec.NodeSelector[key] != value {
+				glog.V(100).Infof("DaemonSet %s does not reflect nodeSelector %s=%s yet", speakerDaemonSetName, key, value)
+
+				return false, nil
+			}
+		}
+
+		if !speaker.IsReady(readinessCheckTimeout) {
+			return false, nil
+		}
+
+		controller, err := deployment.Pull(builder.apiClient, controllerDeploymentName, builder.Definition.Namespace)
+		if err != nil {
+			glog.V(100).Infof("Failed to get Deployment %s due to %v", controllerDeploymentName, err)
+
+			return false, nil
+		}
+
+		return controller.IsReady(readinessCheckTimeout), nil
+	})
+}
+
 // RemoveLabel removes given label from metallb metadata.
 func (builder *Builder) RemoveLabel(key string) *Builder {
 	if valid, _ := builder.validate(); !valid {
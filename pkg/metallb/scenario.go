@@ -0,0 +1,419 @@
+package metallb
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/deployment"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+	"github.com/openshift-kni/eco-goinfra/pkg/service"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// l2AnnouncementRegex matches the service event emitted by the speaker announcing the IP in layer2 mode.
+var l2AnnouncementRegex = regexp.MustCompile(`announcing from node "([^"]+)" with protocol "layer2"`)
+
+// TrafficScenario provides struct for an end-to-end MetalLB traffic check. It wires an IPAddressPool and
+// an advertisement to a LoadBalancer service backed by a deployment and sends traffic to it from a client pod.
+type TrafficScenario struct {
+	apiClient        *clients.Settings
+	name             string
+	nsname           string
+	ipAddressPool    *IPAddressPoolBuilder
+	l2Advertisement  *L2AdvertisementBuilder
+	bgpAdvertisement *BGPAdvertisementBuilder
+	backendContainer *coreV1.Container
+	port             int32
+	clientPod        *pod.Builder
+	teardownSteps    []func() error
+	errorMsg         string
+}
+
+// TrafficScenarioResult provides struct for the outcome of a TrafficScenario run.
+type TrafficScenarioResult struct {
+	LoadBalancerIP  string
+	AnnouncingNodes []string
+	ClientOutput    string
+}
+
+// NewTrafficScenario creates a new instance of TrafficScenario. The backend container has to serve HTTP on
+// the given port. Backend, service and client pod are created in the nsname namespace.
+func NewTrafficScenario(
+	apiClient *clients.Settings,
+	name, nsname string,
+	ipAddressPool *IPAddressPoolBuilder,
+	backendContainer *coreV1.Container,
+	port int32) *TrafficScenario {
+	glog.V(100).Infof("Initializing new MetalLB traffic scenario %s in namespace %s", name, nsname)
+
+	scenario := &TrafficScenario{
+		apiClient:        apiClient,
+		name:             name,
+		nsname:           nsname,
+		ipAddressPool:    ipAddressPool,
+		backendContainer: backendContainer,
+		port:             port,
+	}
+
+	if name == "" {
+		scenario.errorMsg = "traffic scenario 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		scenario.errorMsg = "traffic scenario 'nsname' cannot be empty"
+	}
+
+	if ipAddressPool == nil {
+		scenario.errorMsg = "traffic scenario 'ipAddressPool' cannot be nil"
+	}
+
+	if backendContainer == nil {
+		scenario.errorMsg = "traffic scenario 'backendContainer' cannot be nil"
+	}
+
+	return scenario
+}
+
+// WithL2Advertisement announces the pool of the scenario using the given L2Advertisement.
+func (scenario *TrafficScenario) WithL2Advertisement(advertisement *L2AdvertisementBuilder) *TrafficScenario {
+	if scenario == nil {
+		glog.V(100).Infof("The traffic scenario is uninitialized")
+
+		return scenario
+	}
+
+	if advertisement == nil {
+		scenario.errorMsg = "traffic scenario L2Advertisement cannot be nil"
+
+		return scenario
+	}
+
+	scenario.l2Advertisement = advertisement
+
+	return scenario
+}
+
+// WithBGPAdvertisement announces the pool of the scenario using the given BGPAdvertisement.
+func (scenario *TrafficScenario) WithBGPAdvertisement(advertisement *BGPAdvertisementBuilder) *TrafficScenario {
+	if scenario == nil {
+		glog.V(100).Infof("The traffic scenario is uninitialized")
+
+		return scenario
+	}
+
+	if advertisement == nil {
+		scenario.errorMsg = "traffic scenario BGPAdvertisement cannot be nil"
+
+		return scenario
+	}
+
+	scenario.bgpAdvertisement = advertisement
+
+	return scenario
+}
+
+// WithClientPod sends the traffic from the given running pod, e.g. a pod attached to an external network.
+// The pod must provide the curl binary. By default a client pod using the backend image is created.
+func (scenario *TrafficScenario) WithClientPod(clientPod *pod.Builder) *TrafficScenario {
+	if scenario == nil {
+		glog.V(100).Infof("The traffic scenario is uninitialized")
+
+		return scenario
+	}
+
+	if clientPod == nil {
+		scenario.errorMsg = "traffic scenario client pod cannot be nil"
+
+		return scenario
+	}
+
+	scenario.clientPod = clientPod
+
+	return scenario
+}
+
+// Run creates the scenario resources, waits for the service IP, sends traffic from the client pod to it
+// and reports the nodes announcing the IP. Resources created by Run are removed by Teardown.
+func (scenario *TrafficScenario) Run(timeout time.Duration) (*TrafficScenarioResult, error) {
+	if err := scenario.validate(); err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Running MetalLB traffic scenario %s in namespace %s", scenario.name, scenario.nsname)
+
+	if err := scenario.createMetalLBResources(); err != nil {
+		return nil, err
+	}
+
+	lbService, err := scenario.createBackendAndService(timeout)
+	if err != nil {
+		return nil, err
+	}
+
+	ingressIPs, err := lbService.GetLoadBalancerIngressIPs()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ingressIPs) == 0 {
+		return nil, fmt.Errorf("service %s has no load balancer ingress IP", scenario.name)
+	}
+
+	result := &TrafficScenarioResult{LoadBalancerIP: ingressIPs[0]}
+
+	result.ClientOutput, err = scenario.sendTraffic(result.LoadBalancerIP, timeout)
+	if err != nil {
+		return result, err
+	}
+
+	result.AnnouncingNodes, err = scenario.getAnnouncingNodes(result.LoadBalancerIP)
+
+	return result, err
+}
+
+// Teardown removes all resources created by Run in reverse order of creation.
+// Pools and advertisements that existed before Run are left untouched.
+func (scenario *TrafficScenario) Teardown() error {
+	if scenario == nil {
+		glog.V(100).Infof("The traffic scenario is uninitialized")
+
+		return fmt.Errorf("error: received nil traffic scenario")
+	}
+
+	glog.V(100).Infof("Tearing down MetalLB traffic scenario %s in namespace %s", scenario.name, scenario.nsname)
+
+	var errorMessages []string
+
+	for index := len(scenario.teardownSteps) - 1; index >= 0; index-- {
+		if err := scenario.teardownSteps[index](); err != nil {
+			errorMessages = append(errorMessages, err.Error())
+		}
+	}
+
+	scenario.teardownSteps = nil
+
+	if len(errorMessages) > 0 {
+		return fmt.Errorf("failed to tear down traffic scenario %s: %s", scenario.name, strings.Join(errorMessages, "; "))
+	}
+
+	return nil
+}
+
+func (scenario *TrafficScenario) createMetalLBResources() error {
+	if !scenario.ipAddressPool.Exists() {
+		if _, err := scenario.ipAddressPool.Create(); err != nil {
+			return fmt.Errorf("failed to create IPAddressPool: %w", err)
+		}
+
+		scenario.addTeardownStep(func() error {
+			_, err := scenario.ipAddressPool.Delete()
+
+			return err
+		})
+	}
+
+	if scenario.l2Advertisement != nil && !scenario.l2Advertisement.Exists() {
+		if _, err := scenario.l2Advertisement.Create(); err != nil {
+			return fmt.Errorf("failed to create L2Advertisement: %w", err)
+		}
+
+		scenario.addTeardownStep(func() error {
+			_, err := scenario.l2Advertisement.Delete()
+
+			return err
+		})
+	}
+
+	if scenario.bgpAdvertisement != nil && !scenario.bgpAdvertisement.Exists() {
+		if _, err := scenario.bgpAdvertisement.Create(); err != nil {
+			return fmt.Errorf("failed to create BGPAdvertisement: %w", err)
+		}
+
+		scenario.addTeardownStep(func() error {
+			_, err := scenario.bgpAdvertisement.Delete()
+
+			return err
+		})
+	}
+
+	return nil
+}
+
+func (scenario *TrafficScenario) createBackendAndService(timeout time.Duration) (*service.Builder, error) {
+	labels := map[string]string{"metallb-traffic-scenario": scenario.name}
+
+	backend := deployment.NewBuilder(
+		scenario.apiClient, scenario.name+"-backend", scenario.nsname, labels, scenario.backendContainer)
+
+	scenario.addTeardownStep(backend.Delete)
+
+	if _, err := backend.CreateAndWaitUntilReady(timeout); err != nil {
+		return nil, fmt.Errorf("failed to create backend deployment: %w", err)
+	}
+
+	servicePort, err := service.DefineServicePort(scenario.port, scenario.port, coreV1.ProtocolTCP)
+	if err != nil {
+		return nil, err
+	}
+
+	lbService := service.NewBuilder(scenario.apiClient, scenario.name, scenario.nsname, labels, *servicePort).
+		WithAddressPool(scenario.ipAddressPool.Definition.Name)
+
+	if _, err := lbService.Create(); err != nil {
+		return nil, fmt.Errorf("failed to create LoadBalancer service: %w", err)
+	}
+
+	scenario.addTeardownStep(lbService.Delete)
+
+	if err := lbService.WaitUntilLoadBalancerIngress(timeout); err != nil {
+		return nil, fmt.Errorf("service %s did not get a load balancer IP: %w", scenario.name, err)
+	}
+
+	if err := lbService.WaitUntilEndpointsReady(1, timeout); err != nil {
+		return nil, fmt.Errorf("service %s has no ready endpoints: %w", scenario.name, err)
+	}
+
+	return lbService, nil
+}
+
+func (scenario *TrafficScenario) sendTraffic(loadBalancerIP string, timeout time.Duration) (string, error) {
+	if scenario.clientPod == nil {
+		clientPod := pod.NewBuilder(
+			scenario.apiClient, scenario.name+"-client", scenario.nsname, scenario.backendContainer.Image)
+
+		scenario.addTeardownStep(func() error {
+			_, err := clientPod.Delete()
+
+			return err
+		})
+
+		if _, err := clientPod.CreateAndWaitUntilRunning(timeout); err != nil {
+			return "", fmt.Errorf("failed to create client pod: %w", err)
+		}
+
+		scenario.clientPod = clientPod
+	}
+
+	url := fmt.Sprintf("http://%s/", net.JoinHostPort(loadBalancerIP, fmt.Sprint(scenario.port)))
+
+	glog.V(100).Infof("Sending traffic from pod %s to %s", scenario.clientPod.Definition.Name, url)
+
+	var output string
+
+	err := wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		buffer, err := scenario.clientPod.ExecCommand([]string{"curl", "-sS", "--max-time", "5", url})
+		output = buffer.String()
+
+		if err != nil {
+			glog.V(100).Infof("Traffic to %s failed due to %v: %s", url, err, output)
+
+			return false, nil
+		}
+
+		return true, nil
+	})
+
+	if err != nil {
+		return output, fmt.Errorf("failed to reach %s from pod %s: %w", url, scenario.clientPod.Definition.Name, err)
+	}
+
+	return output, nil
+}
+
+// getAnnouncingNodes returns the node announcing the IP in layer2 mode or the nodes
+// advertising the IP over BGP.
+func (scenario *TrafficScenario) getAnnouncingNodes(loadBalancerIP string) ([]string, error) {
+	if scenario.l2Advertisement != nil {
+		events, err := scenario.apiClient.CoreV1Interface.Events(scenario.nsname).List(
+			context.TODO(), metaV1.ListOptions{
+				FieldSelector: fmt.Sprintf("involvedObject.kind=Service,involvedObject.name=%s", scenario.name),
+			})
+
+		if err != nil {
+			return nil, fmt.Errorf("failed to list events of service %s: %w", scenario.name, err)
+		}
+
+		var nodeName string
+
+		latest := time.Time{}
+
+		for _, event := range events.Items {
+			match := l2AnnouncementRegex.FindStringSubmatch(event.Message)
+			if match != nil && !event.LastTimestamp.Time.Before(latest) {
+				nodeName = match[1]
+				latest = event.LastTimestamp.Time
+			}
+		}
+
+		if nodeName != "" {
+			return []string{nodeName}, nil
+		}
+	}
+
+	return scenario.getBGPAnnouncingNodes(loadBalancerIP)
+}
+
+func (scenario *TrafficScenario) getBGPAnnouncingNodes(loadBalancerIP string) ([]string, error) {
+	speakerPods, err := ListSpeakerPods(scenario.apiClient, scenario.ipAddressPool.Definition.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	ipFamily, prefix := coreV1.IPv4Protocol, loadBalancerIP+"/32"
+	if net.ParseIP(loadBalancerIP).To4() == nil {
+		ipFamily, prefix = coreV1.IPv6Protocol, loadBalancerIP+"/128"
+	}
+
+	var nodeNames []string
+
+	for _, speakerPod := range speakerPods {
+		routes, err := GetBGPRoutes(speakerPod, ipFamily)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, advertised := range routes.Advertised() {
+			if advertised == prefix {
+				nodeNames = append(nodeNames, speakerPod.Object.Spec.NodeName)
+			}
+		}
+	}
+
+	if len(nodeNames) == 0 {
+		return nil, fmt.Errorf("no node announces load balancer IP %s", loadBalancerIP)
+	}
+
+	return nodeNames, nil
+}
+
+func (scenario *TrafficScenario) addTeardownStep(step func() error) {
+	scenario.teardownSteps = append(scenario.teardownSteps, step)
+}
+
+func (scenario *TrafficScenario) validate() error {
+	if scenario == nil {
+		return fmt.Errorf("error: received nil traffic scenario")
+	}
+
+	if scenario.apiClient == nil {
+		scenario.errorMsg = "traffic scenario cannot have nil apiClient"
+	}
+
+	if scenario.errorMsg == "" && scenario.l2Advertisement == nil && scenario.bgpAdvertisement == nil {
+		scenario.errorMsg = "traffic scenario requires an L2Advertisement or a BGPAdvertisement"
+	}
+
+	if scenario.errorMsg != "" {
+		return fmt.Errorf(scenario.errorMsg)
+	}
+
+	return nil
+}
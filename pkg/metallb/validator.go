@@ -0,0 +1,358 @@
+package metallb
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	metalLbV1Beta1 "go.universe.tf/metallb/api/v1beta1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ConfigValidator provides struct for an offline check of a set of MetalLB resources. It reports
+// misconfigurations MetalLB silently ignores, such as overlapping pools or dangling references.
+type ConfigValidator struct {
+	IPAddressPools    []metalLbV1Beta1.IPAddressPool
+	L2Advertisements  []metalLbV1Beta1.L2Advertisement
+	BGPAdvertisements []metalLbV1Beta1.BGPAdvertisement
+	BGPPeers          []metalLbV1Beta1.BGPPeer
+	BFDProfiles       []metalLbV1Beta1.BFDProfile
+	Communities       []metalLbV1Beta1.Community
+}
+
+// addressRange provides struct for an inclusive range of IP addresses of a pool.
+type addressRange struct {
+	pool  string
+	value string
+	first net.IP
+	last  net.IP
+}
+
+// NewConfigValidator creates a new empty instance of ConfigValidator.
+func NewConfigValidator() *ConfigValidator {
+	return &ConfigValidator{}
+}
+
+// PullConfigValidator creates a ConfigValidator holding the MetalLB resources currently defined in the namespace.
+func PullConfigValidator(apiClient *clients.Settings, nsname string) (*ConfigValidator, error) {
+	glog.V(100).Infof("Collecting MetalLB configuration in namespace %s", nsname)
+
+	if apiClient == nil {
+		return nil, fmt.Errorf("failed to pull MetalLB configuration, apiClient is nil")
+	}
+
+	if nsname == "" {
+		return nil, fmt.Errorf("failed to pull MetalLB configuration, 'nsname' parameter is empty")
+	}
+
+	if err := apiClient.AttachScheme(metalLbV1Beta1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add metallb scheme to client schemes")
+
+		return nil, err
+	}
+
+	var (
+		pools             metalLbV1Beta1.IPAddressPoolList
+		l2Advertisements  metalLbV1Beta1.L2AdvertisementList
+		bgpAdvertisements metalLbV1Beta1.BGPAdvertisementList
+		bgpPeers          metalLbV1Beta1.BGPPeerList
+		bfdProfiles       metalLbV1Beta1.BFDProfileList
+		communities       metalLbV1Beta1.CommunityList
+	)
+
+	for _, list := range []goclient.ObjectList{
+		&pools, &l2Advertisements, &bgpAdvertisements, &bgpPeers, &bfdProfiles, &communities} {
+		if err := apiClient.List(context.TODO(), list, goclient.InNamespace(nsname)); err != nil {
+			return nil, fmt.Errorf("failed to list MetalLB resources in namespace %s: %w", nsname, err)
+		}
+	}
+
+	return &ConfigValidator{
+		IPAddressPools:    pools.Items,
+		L2Advertisements:  l2Advertisements.Items,
+		BGPAdvertisements: bgpAdvertisements.Items,
+		BGPPeers:          bgpPeers.Items,
+		BFDProfiles:       bfdProfiles.Items,
+		Communities:       communities.Items,
+	}, nil
+}
+
+// WithIPAddressPools adds the definitions of the given IPAddressPool builders to the validator.
+func (validator *ConfigValidator) WithIPAddressPools(builders ...*IPAddressPoolBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.IPAddressPools = append(validator.IPAddressPools, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// WithL2Advertisements adds the definitions of the given L2Advertisement builders to the validator.
+func (validator *ConfigValidator) WithL2Advertisements(builders ...*L2AdvertisementBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.L2Advertisements = append(validator.L2Advertisements, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// WithBGPAdvertisements adds the definitions of the given BGPAdvertisement builders to the validator.
+func (validator *ConfigValidator) WithBGPAdvertisements(builders ...*BGPAdvertisementBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.BGPAdvertisements = append(validator.BGPAdvertisements, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// WithBGPPeers adds the definitions of the given BGPPeer builders to the validator.
+func (validator *ConfigValidator) WithBGPPeers(builders ...*BGPPeerBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.BGPPeers = append(validator.BGPPeers, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// WithBFDProfiles adds the definitions of the given BFDProfile builders to the validator.
+func (validator *ConfigValidator) WithBFDProfiles(builders ...*BFDBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.BFDProfiles = append(validator.BFDProfiles, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// WithCommunities adds the definitions of the given Community builders to the validator.
+func (validator *ConfigValidator) WithCommunities(builders ...*CommunityBuilder) *ConfigValidator {
+	for _, builder := range builders {
+		if builder != nil && builder.Definition != nil {
+			validator.Communities = append(validator.Communities, *builder.Definition)
+		}
+	}
+
+	return validator
+}
+
+// Validate checks the MetalLB resources held by the validator and returns all found errors.
+// It reports invalid or overlapping pool addresses, advertisements referencing missing pools or peers,
+// invalid or undefined communities and peers referencing missing BFD profiles.
+func (validator *ConfigValidator) Validate() []error {
+	glog.V(100).Infof("Validating MetalLB configuration")
+
+	errs := validator.validateIPAddressPools()
+
+	for _, advertisement := range validator.L2Advertisements {
+		errs = append(errs, validator.validatePoolReferences("L2Advertisement", advertisement.Name,
+			advertisement.Spec.IPAddressPools, advertisement.Spec.IPAddressPoolSelectors)...)
+	}
+
+	for _, advertisement := range validator.BGPAdvertisements {
+		errs = append(errs, validator.validatePoolReferences("BGPAdvertisement", advertisement.Name,
+			advertisement.Spec.IPAddressPools, advertisement.Spec.IPAddressPoolSelectors)...)
+		errs = append(errs, validator.validateBGPAdvertisementReferences(advertisement)...)
+	}
+
+	bfdProfileNames := make(map[string]bool)
+
+	for _, bfdProfile := range validator.BFDProfiles {
+		bfdProfileNames[bfdProfile.Name] = true
+	}
+
+	for _, bgpPeer := range validator.BGPPeers {
+		if bgpPeer.Spec.BFDProfile != "" && !bfdProfileNames[bgpPeer.Spec.BFDProfile] {
+			errs = append(errs, fmt.Errorf("missing BFDProfile %s referenced by BGPPeer %s",
+				bgpPeer.Spec.BFDProfile, bgpPeer.Name))
+		}
+	}
+
+	for _, community := range validator.Communities {
+		for _, alias := range community.Spec.Communities {
+			if !isValidCommunity(alias.Value) {
+				errs = append(errs, fmt.Errorf("invalid value %s of alias %s in Community %s",
+					alias.Value, alias.Name, community.Name))
+			}
+		}
+	}
+
+	return errs
+}
+
+func (validator *ConfigValidator) validateIPAddressPools() []error {
+	var (
+		errs   []error
+		ranges []addressRange
+	)
+
+	for _, pool := range validator.IPAddressPools {
+		for _, address := range pool.Spec.Addresses {
+			poolRange, err := parseAddressRange(pool.Name, address)
+			if err != nil {
+				errs = append(errs, err)
+
+				continue
+			}
+
+			for _, other := range ranges {
+				if bytes.Compare(poolRange.first, other.last) <= 0 && bytes.Compare(other.first, poolRange.last) <= 0 {
+					errs = append(errs, fmt.Errorf("range %s of IPAddressPool %s overlaps with range %s of IPAddressPool %s",
+						poolRange.value, poolRange.pool, other.value, other.pool))
+				}
+			}
+
+			ranges = append(ranges, poolRange)
+		}
+	}
+
+	return errs
+}
+
+func (validator *ConfigValidator) validatePoolReferences(
+	kind, name string, poolNames []string, poolSelectors []metaV1.LabelSelector) []error {
+	var errs []error
+
+	for _, poolName := range poolNames {
+		if !validator.hasIPAddressPool(poolName) {
+			errs = append(errs, fmt.Errorf("%s %s references missing IPAddressPool %s", kind, name, poolName))
+		}
+	}
+
+	for index := range poolSelectors {
+		selector, err := metaV1.LabelSelectorAsSelector(&poolSelectors[index])
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s %s has invalid IPAddressPool selector: %w", kind, name, err))
+
+			continue
+		}
+
+		if !validator.hasIPAddressPoolMatching(selector) {
+			errs = append(errs, fmt.Errorf("%s %s IPAddressPool selector %s matches no IPAddressPool",
+				kind, name, selector.String()))
+		}
+	}
+
+	return errs
+}
+
+func (validator *ConfigValidator) validateBGPAdvertisementReferences(
+	advertisement metalLbV1Beta1.BGPAdvertisement) []error {
+	var errs []error
+
+	for _, peerName := range advertisement.Spec.Peers {
+		found := false
+
+		for _, bgpPeer := range validator.BGPPeers {
+			if bgpPeer.Name == peerName {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			errs = append(errs, fmt.Errorf("missing BGPPeer %s referenced by BGPAdvertisement %s",
+				peerName, advertisement.Name))
+		}
+	}
+
+	for _, community := range advertisement.Spec.Communities {
+		if isValidCommunity(community) || validator.hasCommunityAlias(community) {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("community %s of BGPAdvertisement %s is neither valid nor a defined alias",
+			community, advertisement.Name))
+	}
+
+	return errs
+}
+
+func (validator *ConfigValidator) hasIPAddressPool(poolName string) bool {
+	for _, pool := range validator.IPAddressPools {
+		if pool.Name == poolName {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (validator *ConfigValidator) hasIPAddressPoolMatching(selector labels.Selector) bool {
+	for _, pool := range validator.IPAddressPools {
+		if selector.Matches(labels.Set(pool.Labels)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (validator *ConfigValidator) hasCommunityAlias(aliasName string) bool {
+	for _, community := range validator.Communities {
+		for _, alias := range community.Spec.Communities {
+			if alias.Name == aliasName {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// parseAddressRange parses a pool address given either in CIDR notation or as first-last range.
+func parseAddressRange(poolName, address string) (addressRange, error) {
+	poolRange := addressRange{pool: poolName, value: address}
+
+	if strings.Contains(address, "-") {
+		bounds := strings.SplitN(address, "-", 2)
+		poolRange.first = net.ParseIP(strings.TrimSpace(bounds[0]))
+		poolRange.last = net.ParseIP(strings.TrimSpace(bounds[1]))
+
+		if poolRange.first == nil || poolRange.last == nil ||
+			(poolRange.first.To4() == nil) != (poolRange.last.To4() == nil) ||
+			bytes.Compare(poolRange.first.To16(), poolRange.last.To16()) > 0 {
+			return poolRange, fmt.Errorf("invalid address range %s in IPAddressPool %s", address, poolName)
+		}
+
+		poolRange.first = poolRange.first.To16()
+		poolRange.last = poolRange.last.To16()
+
+		return poolRange, nil
+	}
+
+	_, ipNet, err := net.ParseCIDR(address)
+	if err != nil {
+		return poolRange, fmt.Errorf("invalid CIDR %s in IPAddressPool %s: %w", address, poolName, err)
+	}
+
+	poolRange.first = ipNet.IP.To16()
+	poolRange.last = make(net.IP, net.IPv6len)
+
+	// The mask of an IPv4 network covers only the last 4 bytes of its 16 bytes form.
+	maskOffset := net.IPv6len - len(ipNet.Mask)
+
+	for index := range poolRange.last {
+		poolRange.last[index] = poolRange.first[index]
+
+		if index >= maskOffset {
+			poolRange.last[index] |= ^ipNet.Mask[index-maskOffset]
+		}
+	}
+
+	return poolRange, nil
+}
@@ -18,6 +18,7 @@ import (
 type Builder struct {
 	Definition        *nadV1.NetworkAttachmentDefinition
 	Object            *nadV1.NetworkAttachmentDefinition
+	masterPlugin      *MasterPlugin
 	metaPluginConfigs []Plugin
 	apiClient         *clients.Settings
 	errorMsg          string
@@ -144,7 +145,7 @@ func (builder *Builder) Delete() error {
 	}
 
 	err := builder.apiClient.NetworkAttachmentDefinitions(builder.Definition.Namespace).Delete(
-		context.Background(), builder.Definition.Namespace, metaV1.DeleteOptions{})
+		context.Background(), builder.Definition.Name, metaV1.DeleteOptions{})
 
 	if err != nil {
 		return fmt.Errorf("fail to delete NAD object due to: %w", err)
@@ -164,7 +165,25 @@ func (builder *Builder) Update() (*Builder, error) {
 	glog.V(100).Infof("Updating NetworkAttachmentDefinition %s in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	var err error
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update NetworkAttachmentDefinition, object does not exist on cluster")
+	}
+
+	err := builder.fillConfigureString()
+	if err != nil {
+		return builder, fmt.Errorf("failed to update NAD object, could not marshal configuration: %w", err)
+	}
+
+	if builder.Definition.Spec.Config != "" {
+		masterPlugin, err := ParseConfig(builder.Definition.Spec.Config)
+		if err != nil {
+			return builder, err
+		}
+
+		if err = ValidateConfig(masterPlugin); err != nil {
+			return builder, fmt.Errorf("failed to update NAD object, invalid configuration: %w", err)
+		}
+	}
 
 	builder.Definition.CreationTimestamp = metaV1.Time{}
 	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
@@ -187,8 +206,9 @@ func (builder *Builder) Exists() bool {
 	glog.V(100).Infof("Checking if NetworkAttachmentDefinition %s exists in namespace %s",
 		builder.Definition.Name, builder.Definition.Namespace)
 
-	_, err := builder.apiClient.NetworkAttachmentDefinitions(builder.Definition.Namespace).Get(context.Background(),
-		builder.Definition.Name, metaV1.GetOptions{})
+	var err error
+	builder.Object, err = builder.apiClient.NetworkAttachmentDefinitions(builder.Definition.Namespace).Get(
+		context.Background(), builder.Definition.Name, metaV1.GetOptions{})
 
 	return nil == err || !k8serrors.IsNotFound(err)
 }
@@ -223,12 +243,27 @@ func (builder *Builder) fillConfigureString() error {
 		return nil
 	}
 
-	nadConfig := &MasterPlugin{
+	var nadConfig interface{} = &MasterPlugin{
 		CniVersion: "0.4.0",
 		Name:       builder.Definition.Name,
 		Plugins:    &builder.metaPluginConfigs,
 	}
 
+	// Meta plugins are chained after the master plugin when both are defined.
+	if builder.masterPlugin != nil {
+		chainedPlugins := []interface{}{builder.masterPlugin}
+
+		for index := range builder.metaPluginConfigs {
+			chainedPlugins = append(chainedPlugins, &builder.metaPluginConfigs[index])
+		}
+
+		nadConfig = &chainedPluginList{
+			CniVersion: "0.4.0",
+			Name:       builder.masterPlugin.Name,
+			Plugins:    chainedPlugins,
+		}
+	}
+
 	var nadConfigJSONString []byte
 
 	nadConfigJSONString, err := json.Marshal(nadConfig)
@@ -264,6 +299,27 @@ func (builder *Builder) WithMasterPlugin(masterPlugin *MasterPlugin) *Builder {
 	}
 
 	builder.Definition.Spec.Config = string(masterPluginSting)
+	builder.masterPlugin = masterPlugin
+
+	return builder
+}
+
+// WithMetaPlugin chains the given plugin, e.g. TuningSysctlPlugin, PortMapPlugin, BandwidthPlugin or
+// FirewallPlugin, after the master plugin of the NetworkAttachmentDefinition.
+func (builder *Builder) WithMetaPlugin(plugin *Plugin) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding metaPlugin %v to NAD %s", plugin, builder.Definition.Name)
+
+	if plugin == nil {
+		builder.errorMsg = "error to add empty metaPlugin to NAD"
+
+		return builder
+	}
+
+	builder.metaPluginConfigs = append(builder.metaPluginConfigs, *plugin)
 
 	return builder
 }
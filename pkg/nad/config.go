@@ -0,0 +1,555 @@
+package nad
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/golang/glog"
+	"k8s.io/utils/strings/slices"
+)
+
+// ParseConfig parses a NetworkAttachmentDefinition CNI configuration into a MasterPlugin. A single plugin
+// configuration is returned with its Type set, a plugin list with its Plugins set. Fields unknown to the
+// typed model are preserved in the Extra maps and written back when the configuration is marshaled again.
+func ParseConfig(config string) (*MasterPlugin, error) {
+	glog.V(100).Infof("Parsing NetworkAttachmentDefinition configuration %s", config)
+
+	if config == "" {
+		return nil, fmt.Errorf("failed to parse NAD configuration, configuration is empty")
+	}
+
+	masterPlugin := &MasterPlugin{}
+
+	if err := json.Unmarshal([]byte(config), masterPlugin); err != nil {
+		glog.V(100).Infof("Failed to parse NAD configuration due to %s", err.Error())
+
+		return nil, fmt.Errorf("failed to parse NAD configuration: %w", err)
+	}
+
+	return masterPlugin, nil
+}
+
+// ValidateConfig checks that the MasterPlugin defines the fields required by its CNI type,
+// including the chained plugins and IPAM configuration.
+func ValidateConfig(masterPlugin *MasterPlugin) error {
+	if masterPlugin == nil {
+		return fmt.Errorf("NAD configuration is undefined")
+	}
+
+	if masterPlugin.Name == "" {
+		return fmt.Errorf("NAD configuration 'name' is empty")
+	}
+
+	if masterPlugin.Plugins != nil {
+		if masterPlugin.Type != "" {
+			return fmt.Errorf("NAD configuration can not define both 'type' and 'plugins'")
+		}
+
+		if len(*masterPlugin.Plugins) == 0 {
+			return fmt.Errorf("NAD configuration 'plugins' is empty")
+		}
+
+		for index, plugin := range *masterPlugin.Plugins {
+			if err := validatePlugin(plugin); err != nil {
+				return fmt.Errorf("invalid plugin %d in NAD configuration: %w", index, err)
+			}
+		}
+
+		return nil
+	}
+
+	return validateMasterPlugin(masterPlugin)
+}
+
+// GetMasterPlugin parses the configuration of the NetworkAttachmentDefinition definition into a MasterPlugin.
+// The returned MasterPlugin can be mutated and applied back with WithMasterPluginConfig followed by Update.
+func (builder *Builder) GetMasterPlugin() (*MasterPlugin, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Parsing configuration of NetworkAttachmentDefinition %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return ParseConfig(builder.Definition.Spec.Config)
+}
+
+// WithMasterPluginConfig validates the given MasterPlugin and replaces the configuration in the
+// NetworkAttachmentDefinition spec with it. Unlike WithMasterPlugin it may redefine an existing configuration.
+func (builder *Builder) WithMasterPluginConfig(masterPlugin *MasterPlugin) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining configuration of NAD %s with %v", builder.Definition.Name, masterPlugin)
+
+	if err := ValidateConfig(masterPlugin); err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	masterPluginString, err := json.Marshal(masterPlugin)
+	if err != nil {
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	builder.Definition.Spec.Config = string(masterPluginString)
+	builder.masterPlugin = nil
+	builder.metaPluginConfigs = nil
+
+	return builder
+}
+
+func validateMasterPlugin(masterPlugin *MasterPlugin) error {
+	switch masterPlugin.Type {
+	case "":
+		return fmt.Errorf("NAD configuration 'type' is empty")
+	case "macvlan":
+		if masterPlugin.Mode != "" && !slices.Contains(allowedMacVlanMode, masterPlugin.Mode) {
+			return fmt.Errorf("invalid macvlan mode %s, allowed modes are %v", masterPlugin.Mode, allowedMacVlanMode)
+		}
+	case "bridge":
+		if masterPlugin.Bridge == "" {
+			return fmt.Errorf("bridge plugin requires 'bridge'")
+		}
+	case "vlan":
+		if masterPlugin.Master == "" {
+			return fmt.Errorf("vlan plugin requires 'master'")
+		}
+
+		if masterPlugin.VlanID > 4094 {
+			return fmt.Errorf("vlan plugin 'vlanId' is greater than 4094")
+		}
+	case "host-device":
+		if masterPlugin.Device == "" && masterPlugin.PCIBusID == "" && masterPlugin.DeviceID == "" &&
+			masterPlugin.Extra["hwaddr"] == nil && masterPlugin.Extra["kernelpath"] == nil {
+			return fmt.Errorf("host-device plugin requires one of 'device', 'pciBusID', 'hwaddr' or 'kernelpath'")
+		}
+	case "sriov":
+		if masterPlugin.Vlan > 4094 {
+			return fmt.Errorf("sriov plugin 'vlan' is greater than 4094")
+		}
+	case "bond":
+		if !slices.Contains(allowedBondMode, masterPlugin.Mode) {
+			return fmt.Errorf("invalid bond mode %s, allowed modes are %v", masterPlugin.Mode, allowedBondMode)
+		}
+
+		if len(masterPlugin.Links) == 0 {
+			return fmt.Errorf("bond plugin requires 'links'")
+		}
+	case "ovn-k8s-cni-overlay":
+		if !slices.Contains(allowedOVNTopology, masterPlugin.Topology) {
+			return fmt.Errorf("invalid ovn-k8s-cni-overlay topology %s, allowed topologies are %v",
+				masterPlugin.Topology, allowedOVNTopology)
+		}
+
+		if masterPlugin.NetAttachDefName == "" {
+			return fmt.Errorf("ovn-k8s-cni-overlay plugin requires 'netAttachDefName'")
+		}
+	}
+
+	return validateIPAM(masterPlugin.Ipam)
+}
+
+func validatePlugin(plugin Plugin) error {
+	switch plugin.Type {
+	case "":
+		return fmt.Errorf("plugin 'type' is empty")
+	case "bridge":
+		if plugin.Bridge == "" {
+			return fmt.Errorf("bridge plugin requires 'bridge'")
+		}
+	case "macvlan":
+		if plugin.Mode != "" && !slices.Contains(allowedMacVlanMode, plugin.Mode) {
+			return fmt.Errorf("invalid macvlan mode %s, allowed modes are %v", plugin.Mode, allowedMacVlanMode)
+		}
+	case "bond":
+		if len(plugin.Links) == 0 {
+			return fmt.Errorf("bond plugin requires 'links'")
+		}
+	case "firewall":
+		if plugin.Backend != "" && plugin.Backend != "iptables" && plugin.Backend != "firewalld" {
+			return fmt.Errorf("invalid firewall backend %s, allowed backends are iptables and firewalld",
+				plugin.Backend)
+		}
+	}
+
+	return validateIPAM(plugin.Ipam)
+}
+
+func validateIPAM(ipam *IPAM) error {
+	if ipam == nil {
+		return nil
+	}
+
+	switch ipam.Type {
+	case "":
+		return fmt.Errorf("ipam 'type' is empty")
+	case "whereabouts":
+		if ipam.AddrRange == "" && len(ipam.IPRanges) == 0 {
+			return fmt.Errorf("whereabouts ipam requires 'range' or 'ipRanges'")
+		}
+	case "host-local":
+		if len(ipam.Ranges) == 0 && ipam.Extra["subnet"] == nil {
+			return fmt.Errorf("host-local ipam requires 'ranges' or 'subnet'")
+		}
+	}
+
+	return nil
+}
+
+// unmarshalWithExtra unmarshals data into the typed object and returns the fields unknown to its type.
+func unmarshalWithExtra(data []byte, object interface{}) (map[string]json.RawMessage, error) {
+	if err := json.Unmarshal(data, object); err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, knownField := range jsonFieldNames(reflect.TypeOf(object).Elem()) {
+		delete(fields, knownField)
+	}
+
+	return fields, nil
+}
+
+// marshalWithExtra marshals the typed object and merges the unknown fields into the result.
+// Typed fields take precedence over unknown fields with the same name.
+func marshalWithExtra(object interface{}, extra map[string]json.RawMessage) ([]byte, error) {
+	data, err := json.Marshal(object)
+	if err != nil || len(extra) == 0 {
+		return data, err
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for key, value := range extra {
+		if _, found := fields[key]; !found {
+			fields[key] = value
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// jsonFieldNames returns the json names of the struct fields.
+func jsonFieldNames(structType reflect.Type) []string {
+	var names []string
+
+	for index := 0; index < structType.NumField(); index++ {
+		tag := structType.Field(index).Tag.Get("json")
+
+		name := strings.Split(tag, ",")[0]
+		if name == "" || name == "-" {
+			continue
+		}
+
+		names = append(names, name)
+	}
+
+	return names
+}
+
+// stringifyFields converts numeric values of the given fields into json strings, e.g. "mtu": 1500 into
+// "mtu": "1500", so they can be decoded into the string typed Plugin fields. It returns the converted fields.
+func stringifyFields(data []byte, fieldNames ...string) ([]byte, []string, error) {
+	fields := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, nil, err
+	}
+
+	var converted []string
+
+	for _, fieldName := range fieldNames {
+		var number json.Number
+
+		value, found := fields[fieldName]
+		if !found || len(value) == 0 || value[0] == '"' || json.Unmarshal(value, &number) != nil {
+			continue
+		}
+
+		quoted, err := json.Marshal(number.String())
+		if err != nil {
+			return nil, nil, err
+		}
+
+		fields[fieldName] = quoted
+		converted = append(converted, fieldName)
+	}
+
+	if len(converted) == 0 {
+		return data, nil, nil
+	}
+
+	data, err := json.Marshal(fields)
+
+	return data, converted, err
+}
+
+// numberifyFields reverts stringifyFields, so numeric values are written back with their original json type.
+func numberifyFields(data []byte, fieldNames []string) ([]byte, error) {
+	if len(fieldNames) == 0 {
+		return data, nil
+	}
+
+	fields := make(map[string]json.RawMessage)
+
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	for _, fieldName := range fieldNames {
+		var value string
+
+		if err := json.Unmarshal(fields[fieldName], &value); err != nil {
+			continue
+		}
+
+		var number json.Number
+
+		if err := json.Unmarshal([]byte(value), &number); err == nil {
+			fields[fieldName] = json.RawMessage(value)
+		}
+	}
+
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON decodes the MasterPlugin and preserves unknown fields in Extra.
+func (masterPlugin *MasterPlugin) UnmarshalJSON(data []byte) error {
+	type masterPluginAlias MasterPlugin
+
+	alias := (*masterPluginAlias)(masterPlugin)
+
+	extra, err := unmarshalWithExtra(data, alias)
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		masterPlugin.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the MasterPlugin including the preserved unknown fields.
+func (masterPlugin MasterPlugin) MarshalJSON() ([]byte, error) {
+	type masterPluginAlias MasterPlugin
+
+	return marshalWithExtra(masterPluginAlias(masterPlugin), masterPlugin.Extra)
+}
+
+// UnmarshalJSON decodes the Plugin and preserves unknown fields in Extra.
+func (plugin *Plugin) UnmarshalJSON(data []byte) error {
+	type pluginAlias Plugin
+
+	data, numericFields, err := stringifyFields(data, "mtu", "vlan", "miimon")
+	if err != nil {
+		return err
+	}
+
+	alias := (*pluginAlias)(plugin)
+
+	extra, err := unmarshalWithExtra(data, alias)
+	if err != nil {
+		return err
+	}
+
+	plugin.numericFields = numericFields
+
+	if len(extra) > 0 {
+		plugin.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the Plugin including the preserved unknown fields.
+func (plugin Plugin) MarshalJSON() ([]byte, error) {
+	type pluginAlias Plugin
+
+	data, err := marshalWithExtra(pluginAlias(plugin), plugin.Extra)
+	if err != nil {
+		return nil, err
+	}
+
+	return numberifyFields(data, plugin.numericFields)
+}
+
+// UnmarshalJSON decodes the IPAM and preserves unknown fields in Extra.
+func (ipam *IPAM) UnmarshalJSON(data []byte) error {
+	type ipamAlias IPAM
+
+	alias := (*ipamAlias)(ipam)
+
+	extra, err := unmarshalWithExtra(data, alias)
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		ipam.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the IPAM including the preserved unknown fields.
+func (ipam IPAM) MarshalJSON() ([]byte, error) {
+	type ipamAlias IPAM
+
+	return marshalWithExtra(ipamAlias(ipam), ipam.Extra)
+}
+
+// UnmarshalJSON decodes the Capability and preserves unknown fields in Extra.
+func (capability *Capability) UnmarshalJSON(data []byte) error {
+	type capabilityAlias Capability
+
+	extra, err := unmarshalWithExtra(data, (*capabilityAlias)(capability))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		capability.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the Capability including the preserved unknown fields.
+func (capability Capability) MarshalJSON() ([]byte, error) {
+	type capabilityAlias Capability
+
+	return marshalWithExtra(capabilityAlias(capability), capability.Extra)
+}
+
+// UnmarshalJSON decodes the Link and preserves unknown fields in Extra.
+func (link *Link) UnmarshalJSON(data []byte) error {
+	type linkAlias Link
+
+	extra, err := unmarshalWithExtra(data, (*linkAlias)(link))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		link.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the Link including the preserved unknown fields.
+func (link Link) MarshalJSON() ([]byte, error) {
+	type linkAlias Link
+
+	return marshalWithExtra(linkAlias(link), link.Extra)
+}
+
+// UnmarshalJSON decodes the IPRanges and preserves unknown fields in Extra.
+func (ipRanges *IPRanges) UnmarshalJSON(data []byte) error {
+	type ipRangesAlias IPRanges
+
+	extra, err := unmarshalWithExtra(data, (*ipRangesAlias)(ipRanges))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		ipRanges.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the IPRanges including the preserved unknown fields.
+func (ipRanges IPRanges) MarshalJSON() ([]byte, error) {
+	type ipRangesAlias IPRanges
+
+	return marshalWithExtra(ipRangesAlias(ipRanges), ipRanges.Extra)
+}
+
+// UnmarshalJSON decodes the HostLocalRange and preserves unknown fields in Extra.
+func (hostLocalRange *HostLocalRange) UnmarshalJSON(data []byte) error {
+	type hostLocalRangeAlias HostLocalRange
+
+	extra, err := unmarshalWithExtra(data, (*hostLocalRangeAlias)(hostLocalRange))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		hostLocalRange.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the HostLocalRange including the preserved unknown fields.
+func (hostLocalRange HostLocalRange) MarshalJSON() ([]byte, error) {
+	type hostLocalRangeAlias HostLocalRange
+
+	return marshalWithExtra(hostLocalRangeAlias(hostLocalRange), hostLocalRange.Extra)
+}
+
+// UnmarshalJSON decodes the Route and preserves unknown fields in Extra.
+func (route *Route) UnmarshalJSON(data []byte) error {
+	type routeAlias Route
+
+	extra, err := unmarshalWithExtra(data, (*routeAlias)(route))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		route.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the Route including the preserved unknown fields.
+func (route Route) MarshalJSON() ([]byte, error) {
+	type routeAlias Route
+
+	return marshalWithExtra(routeAlias(route), route.Extra)
+}
+
+// UnmarshalJSON decodes the DNS and preserves unknown fields in Extra.
+func (dns *DNS) UnmarshalJSON(data []byte) error {
+	type dnsAlias DNS
+
+	extra, err := unmarshalWithExtra(data, (*dnsAlias)(dns))
+	if err != nil {
+		return err
+	}
+
+	if len(extra) > 0 {
+		dns.Extra = extra
+	}
+
+	return nil
+}
+
+// MarshalJSON encodes the DNS including the preserved unknown fields.
+func (dns DNS) MarshalJSON() ([]byte, error) {
+	type dnsAlias DNS
+
+	return marshalWithExtra(dnsAlias(dns), dns.Extra)
+}
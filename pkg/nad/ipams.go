@@ -32,3 +32,51 @@ func WhereAboutsAppendRange(ipam *IPAM, ipRange, gateway string) *IPAM {
 
 	return ipam
 }
+
+// IPAMDHCP returns dhcp ipam type. Requires the CNI dhcp daemon running on the nodes.
+func IPAMDHCP() *IPAM {
+	return &IPAM{Type: "dhcp"}
+}
+
+// IPAMHostLocal returns host-local ipam type allocating addresses from the given subnet.
+func IPAMHostLocal(subnet, gateway string) *IPAM {
+	if subnet == "" {
+		return nil
+	}
+
+	return &IPAM{Type: "host-local", Ranges: [][]HostLocalRange{{{Subnet: subnet, Gateway: gateway}}}}
+}
+
+// HostLocalAppendRange returns host-local ipam type with additional address range, e.g. for dual-stack.
+func HostLocalAppendRange(ipam *IPAM, subnet, rangeStart, rangeEnd, gateway string) *IPAM {
+	if ipam == nil || subnet == "" {
+		return nil
+	}
+
+	ipam.Ranges = append(ipam.Ranges, []HostLocalRange{
+		{Subnet: subnet, RangeStart: rangeStart, RangeEnd: rangeEnd, Gateway: gateway}})
+
+	return ipam
+}
+
+// IPAMAppendRoute returns ipam with additional route. An empty gateway uses the default gateway of the range.
+func IPAMAppendRoute(ipam *IPAM, dst, gateway string) *IPAM {
+	if ipam == nil || dst == "" {
+		return nil
+	}
+
+	ipam.Routes = append(ipam.Routes, Route{Dst: dst, GW: gateway})
+
+	return ipam
+}
+
+// IPAMWithDNS returns ipam with DNS configuration.
+func IPAMWithDNS(ipam *IPAM, nameservers []string, domain string, search []string) *IPAM {
+	if ipam == nil || len(nameservers) == 0 {
+		return nil
+	}
+
+	ipam.DNS = &DNS{Nameservers: nameservers, Domain: domain, Search: search}
+
+	return ipam
+}
@@ -2,6 +2,7 @@ package nad
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
@@ -10,7 +11,13 @@ import (
 
 var (
 	// allowedMacVlanMode represents all allowed modes for macvlan plugin type.
-	allowedMacVlanMode      = []string{"bridge", "passthru", "private", "vepa"}
+	allowedMacVlanMode = []string{"bridge", "passthru", "private", "vepa"}
+	// allowedBondMode represents all allowed modes for bond-cni plugin type.
+	allowedBondMode = []string{
+		"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad", "balance-tlb", "balance-alb"}
+	// allowedOVNTopology represents all allowed secondary network topologies for ovn-k8s-cni-overlay plugin type.
+	allowedOVNTopology      = []string{"layer2", "localnet"}
+	allowedLinkState        = []string{"enable", "disable", "auto"}
 	invalidIpamParameterMsg = "invalid ipam parameter"
 )
 
@@ -393,3 +400,851 @@ func (plugin *MasterIPVlanPlugin) GetMasterPluginConfig() (*MasterPlugin, error)
 
 	return plugin.masterPlugin, nil
 }
+
+// MasterHostDevicePlugin provides struct for MasterPlugin set to host-device in NetworkAttachmentDefinition.
+type MasterHostDevicePlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterHostDevicePlugin creates new instance of MasterHostDevicePlugin.
+func NewMasterHostDevicePlugin(name string) *MasterHostDevicePlugin {
+	glog.V(100).Infof(
+		"Initializing new MasterHostDevicePlugin structure %s", name)
+
+	builder := MasterHostDevicePlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       "host-device",
+		},
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterHostDevicePlugin name can not be empty")
+
+		builder.errorMsg = "MasterHostDevicePlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithDevice defines the host interface name moved to the pod by MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithDevice(device string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding device %s to MasterHostDevicePlugin", device)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin")
+	}
+
+	if device == "" {
+		glog.V(100).Infof("error to add device, the name of interface can not be empty")
+
+		plugin.errorMsg = "invalid device parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Device = device
+
+	return plugin
+}
+
+// WithPCIAddress defines the PCI address of the host device moved to the pod by MasterHostDevicePlugin.
+func (plugin *MasterHostDevicePlugin) WithPCIAddress(pciAddress string) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding PCI address %s to MasterHostDevicePlugin", pciAddress)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin")
+	}
+
+	if pciAddress == "" {
+		glog.V(100).Infof("error to add PCI address, the PCI address can not be empty")
+
+		plugin.errorMsg = "invalid pciAddress parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.PCIBusID = pciAddress
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterHostDevicePlugin. Default is empty.
+func (plugin *MasterHostDevicePlugin) WithIPAM(ipam *IPAM) *MasterHostDevicePlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterHostDevicePlugin", ipam)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterHostDevicePlugin")
+	}
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterHostDevicePlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterHostDevicePlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg == "" && plugin.masterPlugin.Device == "" && plugin.masterPlugin.PCIBusID == "" {
+		plugin.errorMsg = "MasterHostDevicePlugin requires either device or pciAddress"
+	}
+
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MaterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// MasterSriovPlugin provides struct for MasterPlugin set to sriov in NetworkAttachmentDefinition. It is used for
+// standalone sriov networks not managed by the SR-IOV operator; the VF is selected via the resource annotation
+// of the NetworkAttachmentDefinition or with WithDeviceID.
+type MasterSriovPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterSriovPlugin creates new instance of MasterSriovPlugin.
+func NewMasterSriovPlugin(name string) *MasterSriovPlugin {
+	glog.V(100).Infof(
+		"Initializing new MasterSriovPlugin structure %s", name)
+
+	builder := MasterSriovPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       "sriov",
+		},
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterSriovPlugin name can not be empty")
+
+		builder.errorMsg = "MasterSriovPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithDeviceID defines the PCI address of the VF used by MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithDeviceID(pciAddress string) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding deviceID %s to MasterSriovPlugin", pciAddress)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+	}
+
+	if pciAddress == "" {
+		glog.V(100).Infof("error to add deviceID, the PCI address can not be empty")
+
+		plugin.errorMsg = "invalid deviceID parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.DeviceID = pciAddress
+
+	return plugin
+}
+
+// WithVlan defines vlan id and vlan QoS class to MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithVlan(vlanID, vlanQoS uint16) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding vlan %d with QoS class %d to MasterSriovPlugin", vlanID, vlanQoS)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+	}
+
+	if vlanID > 4094 {
+		glog.V(100).Infof("error vlan id can not be greater than 4094")
+
+		plugin.errorMsg = "MasterSriovPlugin vlanID is greater than 4094"
+	}
+
+	if vlanQoS > 7 {
+		glog.V(100).Infof("error vlan QoS class can not be greater than 7")
+
+		plugin.errorMsg = "MasterSriovPlugin vlanQoS is greater than 7"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Vlan = vlanID
+	plugin.masterPlugin.VlanQoS = vlanQoS
+
+	return plugin
+}
+
+// WithSpoofChk defines spoof check mode of the VF in MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithSpoofChk(enabled bool) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding spoofchk %t to MasterSriovPlugin", enabled)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+
+		return plugin
+	}
+
+	plugin.masterPlugin.SpoofChk = onOff(enabled)
+
+	return plugin
+}
+
+// WithTrust defines trust mode of the VF in MasterSriovPlugin.
+func (plugin *MasterSriovPlugin) WithTrust(enabled bool) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding trust %t to MasterSriovPlugin", enabled)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+
+		return plugin
+	}
+
+	plugin.masterPlugin.Trust = onOff(enabled)
+
+	return plugin
+}
+
+// WithLinkState defines the VF link state in MasterSriovPlugin. Allowed values are enable, disable and auto.
+func (plugin *MasterSriovPlugin) WithLinkState(linkState string) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding link state %s to MasterSriovPlugin", linkState)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+	}
+
+	if !slices.Contains(allowedLinkState, linkState) {
+		glog.V(100).Infof("error to add link state %s, allowed link states are %v", linkState, allowedLinkState)
+
+		plugin.errorMsg = "invalid linkState parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.LinkState = linkState
+
+	return plugin
+}
+
+// WithTxRate defines minimum and maximum transmit rates of the VF in Mbps in MasterSriovPlugin.
+// Zero means no limit.
+func (plugin *MasterSriovPlugin) WithTxRate(minTxRate, maxTxRate uint16) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding tx rates min %d max %d to MasterSriovPlugin", minTxRate, maxTxRate)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+	}
+
+	if maxTxRate != 0 && minTxRate > maxTxRate {
+		glog.V(100).Infof("error minTxRate can not be greater than maxTxRate")
+
+		plugin.errorMsg = "MasterSriovPlugin minTxRate is greater than maxTxRate"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	minTxRateInt := int(minTxRate)
+	maxTxRateInt := int(maxTxRate)
+	plugin.masterPlugin.MinTxRate = &minTxRateInt
+	plugin.masterPlugin.MaxTxRate = &maxTxRateInt
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterSriovPlugin. Default is empty.
+func (plugin *MasterSriovPlugin) WithIPAM(ipam *IPAM) *MasterSriovPlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterSriovPlugin", ipam)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterSriovPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterSriovPlugin")
+	}
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterSriovPlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterSriovPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MaterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// MasterBondPlugin provides struct for MasterPlugin set to bond-cni in NetworkAttachmentDefinition.
+type MasterBondPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterBondPlugin creates new instance of MasterBondPlugin.
+func NewMasterBondPlugin(name, mode string) *MasterBondPlugin {
+	glog.V(100).Infof(
+		"Initializing new MasterBondPlugin structure %s, with mode %s", name, mode)
+
+	builder := MasterBondPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion: "0.3.1",
+			Name:       name,
+			Type:       "bond",
+			Mode:       mode,
+			Miimon:     "100",
+		},
+	}
+
+	if !slices.Contains(allowedBondMode, mode) {
+		glog.V(100).Infof("error to add mode %s, allowed modes are %v", mode, allowedBondMode)
+
+		builder.errorMsg = "invalid mode parameter"
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterBondPlugin name can not be empty")
+
+		builder.errorMsg = "MasterBondPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithLinks defines the bond slave interfaces of MasterBondPlugin.
+func (plugin *MasterBondPlugin) WithLinks(links []string) *MasterBondPlugin {
+	glog.V(100).Infof("Adding links %v to MasterBondPlugin", links)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+	}
+
+	if len(links) == 0 {
+		glog.V(100).Infof("error to add links, the list of links can not be empty")
+
+		plugin.errorMsg = "invalid links parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Links = nil
+
+	for _, link := range links {
+		plugin.masterPlugin.Links = append(plugin.masterPlugin.Links, Link{Name: link})
+	}
+
+	return plugin
+}
+
+// WithLinksInContainer defines MasterBondPlugin using links already present in the pod, e.g. sriov VFs.
+func (plugin *MasterBondPlugin) WithLinksInContainer() *MasterBondPlugin {
+	glog.V(100).Infof("Adding linksInContainer feature to MasterBondPlugin")
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+
+		return plugin
+	}
+
+	plugin.masterPlugin.LinksInContainer = true
+
+	return plugin
+}
+
+// WithFailOverMac defines fail_over_mac policy of MasterBondPlugin. Allowed values are 0 (none), 1 (active)
+// and 2 (follow).
+func (plugin *MasterBondPlugin) WithFailOverMac(failOverMac int) *MasterBondPlugin {
+	glog.V(100).Infof("Adding failOverMac %d to MasterBondPlugin", failOverMac)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+	}
+
+	if failOverMac < 0 || failOverMac > 2 {
+		glog.V(100).Infof("error failOverMac should be in range 0...2")
+
+		plugin.errorMsg = "invalid failOverMac parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.FailOverMac = failOverMac
+
+	return plugin
+}
+
+// WithMiimon defines link monitoring frequency in milliseconds of MasterBondPlugin. Default is 100.
+func (plugin *MasterBondPlugin) WithMiimon(miimon uint32) *MasterBondPlugin {
+	glog.V(100).Infof("Adding miimon %d to MasterBondPlugin", miimon)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+
+		return plugin
+	}
+
+	plugin.masterPlugin.Miimon = fmt.Sprintf("%d", miimon)
+
+	return plugin
+}
+
+// WithMTU defines MTU of the bond interface in MasterBondPlugin.
+func (plugin *MasterBondPlugin) WithMTU(mtu int) *MasterBondPlugin {
+	glog.V(100).Infof("Adding mtu %d to MasterBondPlugin", mtu)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+	}
+
+	if 1 > mtu || mtu > 9192 {
+		glog.V(100).Infof("error mtu should be in range 1...9192")
+
+		plugin.errorMsg = "invalid mtu parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Mtu = mtu
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterBondPlugin. Default is empty.
+func (plugin *MasterBondPlugin) WithIPAM(ipam *IPAM) *MasterBondPlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterBondPlugin", ipam)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterBondPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterBondPlugin")
+	}
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterBondPlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterBondPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg == "" && len(plugin.masterPlugin.Links) == 0 {
+		plugin.errorMsg = "MasterBondPlugin requires at least one link"
+	}
+
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MaterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// MasterOVNOverlayPlugin provides struct for MasterPlugin set to ovn-k8s-cni-overlay in
+// NetworkAttachmentDefinition. It defines OVN-Kubernetes secondary networks with layer2 or localnet topology.
+type MasterOVNOverlayPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterOVNOverlayPlugin creates new instance of MasterOVNOverlayPlugin. The netAttachDefName has to match
+// the NetworkAttachmentDefinition in <namespace>/<name> format.
+func NewMasterOVNOverlayPlugin(name, netAttachDefName, topology string) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof(
+		"Initializing new MasterOVNOverlayPlugin structure %s, for NAD %s with topology %s",
+		name, netAttachDefName, topology)
+
+	builder := MasterOVNOverlayPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion:       "0.3.1",
+			Name:             name,
+			Type:             "ovn-k8s-cni-overlay",
+			Topology:         topology,
+			NetAttachDefName: netAttachDefName,
+		},
+	}
+
+	if !slices.Contains(allowedOVNTopology, topology) {
+		glog.V(100).Infof("error to add topology %s, allowed topologies are %v", topology, allowedOVNTopology)
+
+		builder.errorMsg = "invalid topology parameter"
+	}
+
+	if len(strings.Split(netAttachDefName, "/")) != 2 {
+		glog.V(100).Infof("error netAttachDefName should be in <namespace>/<name> format")
+
+		builder.errorMsg = "MasterOVNOverlayPlugin netAttachDefName is invalid"
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterOVNOverlayPlugin name can not be empty")
+
+		builder.errorMsg = "MasterOVNOverlayPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithSubnets defines the subnets used by OVN-Kubernetes IPAM in MasterOVNOverlayPlugin.
+func (plugin *MasterOVNOverlayPlugin) WithSubnets(subnets ...string) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof("Adding subnets %v to MasterOVNOverlayPlugin", subnets)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin")
+	}
+
+	if len(subnets) == 0 {
+		glog.V(100).Infof("error to add subnets, the list of subnets can not be empty")
+
+		plugin.errorMsg = "invalid subnets parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Subnets = strings.Join(subnets, ",")
+
+	return plugin
+}
+
+// WithExcludeSubnets defines the subnets excluded from allocation in MasterOVNOverlayPlugin.
+func (plugin *MasterOVNOverlayPlugin) WithExcludeSubnets(subnets ...string) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof("Adding exclude subnets %v to MasterOVNOverlayPlugin", subnets)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin")
+	}
+
+	if len(subnets) == 0 {
+		glog.V(100).Infof("error to add exclude subnets, the list of subnets can not be empty")
+
+		plugin.errorMsg = "invalid excludeSubnets parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.ExcludeSubnets = strings.Join(subnets, ",")
+
+	return plugin
+}
+
+// WithMTU defines MTU of the secondary network in MasterOVNOverlayPlugin.
+func (plugin *MasterOVNOverlayPlugin) WithMTU(mtu int) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof("Adding mtu %d to MasterOVNOverlayPlugin", mtu)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin")
+	}
+
+	if 1 > mtu || mtu > 9192 {
+		glog.V(100).Infof("error mtu should be in range 1...9192")
+
+		plugin.errorMsg = "invalid mtu parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Mtu = mtu
+
+	return plugin
+}
+
+// WithVlanID defines the vlan id of a localnet MasterOVNOverlayPlugin.
+func (plugin *MasterOVNOverlayPlugin) WithVlanID(vlanID uint16) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof("Adding vlanID %d to MasterOVNOverlayPlugin", vlanID)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin")
+
+		return plugin
+	}
+
+	if plugin.masterPlugin.Topology != "localnet" {
+		glog.V(100).Infof("error vlanID is supported only with localnet topology")
+
+		plugin.errorMsg = "MasterOVNOverlayPlugin vlanID requires localnet topology"
+	}
+
+	if vlanID > 4094 {
+		glog.V(100).Infof("error vlan id can not be greater than 4094")
+
+		plugin.errorMsg = "MasterOVNOverlayPlugin vlanID is greater than 4094"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.LocalnetVlanID = vlanID
+
+	return plugin
+}
+
+// WithPhysicalNetworkName defines the OVS bridge mapping name used by a localnet MasterOVNOverlayPlugin.
+// Default is the plugin name.
+func (plugin *MasterOVNOverlayPlugin) WithPhysicalNetworkName(physicalNetworkName string) *MasterOVNOverlayPlugin {
+	glog.V(100).Infof("Adding physicalNetworkName %s to MasterOVNOverlayPlugin", physicalNetworkName)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterOVNOverlayPlugin")
+
+		return plugin
+	}
+
+	if plugin.masterPlugin.Topology != "localnet" {
+		glog.V(100).Infof("error physicalNetworkName is supported only with localnet topology")
+
+		plugin.errorMsg = "MasterOVNOverlayPlugin physicalNetworkName requires localnet topology"
+	}
+
+	if physicalNetworkName == "" {
+		glog.V(100).Infof("error physicalNetworkName can not be empty")
+
+		plugin.errorMsg = "invalid physicalNetworkName parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.PhysicalNetworkName = physicalNetworkName
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterOVNOverlayPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MaterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+// MasterTapPlugin provides struct for MasterPlugin set to tap in NetworkAttachmentDefinition.
+type MasterTapPlugin struct {
+	masterPlugin *MasterPlugin
+	errorMsg     string
+}
+
+// NewMasterTapPlugin creates new instance of MasterTapPlugin.
+func NewMasterTapPlugin(name string) *MasterTapPlugin {
+	glog.V(100).Infof(
+		"Initializing new MasterTapPlugin structure %s", name)
+
+	builder := MasterTapPlugin{
+		masterPlugin: &MasterPlugin{
+			CniVersion:     "0.4.0",
+			Name:           name,
+			Type:           "tap",
+			SelinuxContext: "system_u:system_r:container_t:s0",
+		},
+	}
+
+	if builder.masterPlugin.Name == "" {
+		glog.V(100).Infof("error MasterTapPlugin name can not be empty")
+
+		builder.errorMsg = "MasterTapPlugin name is empty"
+	}
+
+	return &builder
+}
+
+// WithOwnership defines the user and group owning the tap device in MasterTapPlugin.
+func (plugin *MasterTapPlugin) WithOwnership(owner, group int) *MasterTapPlugin {
+	glog.V(100).Infof("Adding owner %d and group %d to MasterTapPlugin", owner, group)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterTapPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterTapPlugin")
+	}
+
+	if owner < 0 || group < 0 {
+		glog.V(100).Infof("error owner and group can not be negative")
+
+		plugin.errorMsg = "invalid ownership parameters"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Owner = &owner
+	plugin.masterPlugin.Group = &group
+
+	return plugin
+}
+
+// WithMultiQueue defines MasterTapPlugin creating a multi-queue tap device.
+func (plugin *MasterTapPlugin) WithMultiQueue() *MasterTapPlugin {
+	glog.V(100).Infof("Adding multiQueue feature to MasterTapPlugin")
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterTapPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterTapPlugin")
+
+		return plugin
+	}
+
+	plugin.masterPlugin.MultiQueue = true
+
+	return plugin
+}
+
+// WithSelinuxContext defines the SELinux context of the tap device in MasterTapPlugin.
+// Default is system_u:system_r:container_t:s0.
+func (plugin *MasterTapPlugin) WithSelinuxContext(selinuxContext string) *MasterTapPlugin {
+	glog.V(100).Infof("Adding selinuxcontext %s to MasterTapPlugin", selinuxContext)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterTapPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterTapPlugin")
+	}
+
+	if selinuxContext == "" {
+		glog.V(100).Infof("error selinuxcontext can not be empty")
+
+		plugin.errorMsg = "invalid selinuxContext parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.SelinuxContext = selinuxContext
+
+	return plugin
+}
+
+// WithBridge defines the bridge the tap device is attached to in MasterTapPlugin.
+func (plugin *MasterTapPlugin) WithBridge(bridgeName string) *MasterTapPlugin {
+	glog.V(100).Infof("Adding bridge %s to MasterTapPlugin", bridgeName)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterTapPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterTapPlugin")
+	}
+
+	if bridgeName == "" {
+		glog.V(100).Infof("error bridge name can not be empty")
+
+		plugin.errorMsg = "invalid bridge parameter"
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Bridge = bridgeName
+
+	return plugin
+}
+
+// WithIPAM defines IPAM configuration to MasterTapPlugin. Default is empty.
+func (plugin *MasterTapPlugin) WithIPAM(ipam *IPAM) *MasterTapPlugin {
+	glog.V(100).Infof("Adding IPAM configuration %v to MasterTapPlugin", ipam)
+
+	if plugin.masterPlugin == nil {
+		glog.V(100).Infof(msg.UndefinedCrdObjectErrString("MasterTapPlugin"))
+		plugin.errorMsg = msg.UndefinedCrdObjectErrString("MasterTapPlugin")
+	}
+
+	if ipam == nil {
+		glog.V(100).Infof("error adding empty ipam to MasterTapPlugin")
+
+		plugin.errorMsg = invalidIpamParameterMsg
+	}
+
+	if plugin.errorMsg != "" {
+		return plugin
+	}
+
+	plugin.masterPlugin.Ipam = ipam
+
+	return plugin
+}
+
+// GetMasterPluginConfig returns master plugin if error does not occur.
+func (plugin *MasterTapPlugin) GetMasterPluginConfig() (*MasterPlugin, error) {
+	if plugin.errorMsg != "" {
+		return nil, fmt.Errorf("error to build MaterPlugin config due to :%s", plugin.errorMsg)
+	}
+
+	return plugin.masterPlugin, nil
+}
+
+func onOff(enabled bool) string {
+	if enabled {
+		return "on"
+	}
+
+	return "off"
+}
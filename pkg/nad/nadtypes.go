@@ -1,14 +1,23 @@
 package nad
 
+import "encoding/json"
+
 // Capability tells if the plugin supports MAC.
 type (
 	Capability struct {
-		Mac bool `json:"mac,omitempty"`
+		Mac          bool `json:"mac,omitempty"`
+		PortMappings bool `json:"portMappings,omitempty"`
+		Bandwidth    bool `json:"bandwidth,omitempty"`
+		IPs          bool `json:"ips,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
 	}
 
 	// Link contains the link name of a link.
 	Link struct {
 		Name string `json:"name,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
 	}
 
 	// Plugin contains all plugin details and information of a single plugin.
@@ -39,6 +48,16 @@ type (
 		Group            int               `json:"group,omitempty"`
 		MultiQueue       bool              `json:"multiQueue,omitempty"`
 		SelinuxContext   string            `json:"selinuxcontext,omitempty"`
+		SNAT             *bool             `json:"snat,omitempty"`
+		IngressRate      int               `json:"ingressRate,omitempty"`
+		IngressBurst     int               `json:"ingressBurst,omitempty"`
+		EgressRate       int               `json:"egressRate,omitempty"`
+		EgressBurst      int               `json:"egressBurst,omitempty"`
+		Backend          string            `json:"backend,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
+		// numericFields contains the string typed fields parsed from numeric json values.
+		numericFields []string
 	}
 
 	// MasterPlugin contains the master plugin configuration for a NAD.
@@ -53,22 +72,98 @@ type (
 		Ipam            *IPAM     `json:"ipam,omitempty"`
 		LinkInContainer bool      `json:"linkInContainer,omitempty"`
 		VlanID          uint16    `json:"vlanId,omitempty"`
+		// host-device plugin configuration.
+		Device   string `json:"device,omitempty"`
+		PCIBusID string `json:"pciBusID,omitempty"`
+		// sriov plugin configuration.
+		DeviceID  string `json:"deviceID,omitempty"`
+		Vlan      uint16 `json:"vlan,omitempty"`
+		VlanQoS   uint16 `json:"vlanQoS,omitempty"`
+		SpoofChk  string `json:"spoofchk,omitempty"`
+		Trust     string `json:"trust,omitempty"`
+		LinkState string `json:"link_state,omitempty"`
+		MinTxRate *int   `json:"min_tx_rate,omitempty"`
+		MaxTxRate *int   `json:"max_tx_rate,omitempty"`
+		// bond-cni plugin configuration.
+		LinksInContainer bool   `json:"linksInContainer,omitempty"`
+		FailOverMac      int    `json:"failOverMac,omitempty"`
+		Miimon           string `json:"miimon,omitempty"`
+		Links            []Link `json:"links,omitempty"`
+		Mtu              int    `json:"mtu,omitempty"`
+		// ovn-k8s-cni-overlay plugin configuration.
+		Topology            string `json:"topology,omitempty"`
+		NetAttachDefName    string `json:"netAttachDefName,omitempty"`
+		Subnets             string `json:"subnets,omitempty"`
+		ExcludeSubnets      string `json:"excludeSubnets,omitempty"`
+		PhysicalNetworkName string `json:"physicalNetworkName,omitempty"`
+		LocalnetVlanID      uint16 `json:"vlanID,omitempty"`
+		// tap plugin configuration.
+		Owner          *int   `json:"owner,omitempty"`
+		Group          *int   `json:"group,omitempty"`
+		MultiQueue     bool   `json:"multiQueue,omitempty"`
+		SelinuxContext string `json:"selinuxcontext,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
+	}
+
+	// chainedPluginList contains a master plugin followed by chained meta plugins.
+	chainedPluginList struct {
+		CniVersion string        `json:"cniVersion,omitempty"`
+		Name       string        `json:"name,omitempty"`
+		Plugins    []interface{} `json:"plugins,omitempty"`
 	}
 
 	// IPRanges contains ip range for WhereAbout IPAM plugin.
 	IPRanges struct {
-		Range   string `json:"range,omitempty"`
-		Gateway string `json:"gateway,omitempty"`
+		Range   string   `json:"range,omitempty"`
+		Gateway string   `json:"gateway,omitempty"`
+		Exclude []string `json:"exclude,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
+	}
+
+	// HostLocalRange contains a single address range for host-local IPAM plugin.
+	HostLocalRange struct {
+		Subnet     string `json:"subnet,omitempty"`
+		RangeStart string `json:"rangeStart,omitempty"`
+		RangeEnd   string `json:"rangeEnd,omitempty"`
+		Gateway    string `json:"gateway,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
+	}
+
+	// Route contains a route returned by the IPAM plugin.
+	Route struct {
+		Dst string `json:"dst,omitempty"`
+		GW  string `json:"gw,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
+	}
+
+	// DNS contains the DNS configuration returned by the IPAM plugin.
+	DNS struct {
+		Nameservers []string `json:"nameservers,omitempty"`
+		Domain      string   `json:"domain,omitempty"`
+		Search      []string `json:"search,omitempty"`
+		Options     []string `json:"options,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
 	}
 
 	// IPAM container the IPAM configuration for a NAD.
 	IPAM struct {
-		Type       string     `json:"type,omitempty"`
-		AddrRange  string     `json:"range,omitempty"`
-		RangeStart string     `json:"range_start,omitempty"`
-		RangeEnd   string     `json:"range_end,omitempty"`
-		Gateway    string     `json:"gateway,omitempty"`
-		Exclude    []string   `json:"exclude,omitempty"`
-		IPRanges   []IPRanges `json:"ipRanges,omitempty"`
+		Type       string             `json:"type,omitempty"`
+		AddrRange  string             `json:"range,omitempty"`
+		RangeStart string             `json:"range_start,omitempty"`
+		RangeEnd   string             `json:"range_end,omitempty"`
+		Gateway    string             `json:"gateway,omitempty"`
+		Exclude    []string           `json:"exclude,omitempty"`
+		IPRanges   []IPRanges         `json:"ipRanges,omitempty"`
+		Ranges     [][]HostLocalRange `json:"ranges,omitempty"`
+		Routes     []Route            `json:"routes,omitempty"`
+		DNS        *DNS               `json:"dns,omitempty"`
+		DataDir    string             `json:"dataDir,omitempty"`
+		// Extra contains the configuration fields unknown to the typed model.
+		Extra map[string]json.RawMessage `json:"-"`
 	}
 )
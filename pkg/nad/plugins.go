@@ -27,3 +27,32 @@ func TuningMacPlugin(macCap bool) *Plugin {
 		Capabilities: &Capability{Mac: macCap},
 	}
 }
+
+// PortMapPlugin returns portmap plugin configuration.
+func PortMapPlugin(snat bool) *Plugin {
+	return &Plugin{
+		Type:         "portmap",
+		Capabilities: &Capability{PortMappings: true},
+		SNAT:         &snat,
+	}
+}
+
+// BandwidthPlugin returns bandwidth plugin configuration. Rates are in bits per second and bursts in bits.
+func BandwidthPlugin(ingressRate, ingressBurst, egressRate, egressBurst int) *Plugin {
+	return &Plugin{
+		Type:         "bandwidth",
+		Capabilities: &Capability{Bandwidth: true},
+		IngressRate:  ingressRate,
+		IngressBurst: ingressBurst,
+		EgressRate:   egressRate,
+		EgressBurst:  egressBurst,
+	}
+}
+
+// FirewallPlugin returns firewall plugin configuration. Allowed backends are iptables and firewalld.
+func FirewallPlugin(backend string) *Plugin {
+	return &Plugin{
+		Type:    "firewall",
+		Backend: backend,
+	}
+}
@@ -39,11 +39,23 @@ func PullOperator(apiClient *clients.Settings) (*OperatorBuilder, error) {
 		},
 	}
 
-	if !builder.Exists() {
+	if err := apiClient.AttachScheme(operatorV1.Install); err != nil {
+		glog.V(100).Infof("Failed to add network.operator scheme to client schemes")
+
+		return nil, err
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("network.operator object %s doesn't exist", clusterNetworkName)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -61,7 +73,7 @@ func (builder *OperatorBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns network.operator object.
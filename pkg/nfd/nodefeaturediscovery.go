@@ -43,6 +43,12 @@ func NewBuilderFromObjectString(apiClient *clients.Settings, almExample string)
 		Definition: nodeFeatureDiscovery,
 	}
 
+	if err := apiClient.AttachScheme(nfdv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add nodeFeatureDiscovery scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add nodeFeatureDiscovery scheme to client schemes: %v", err)
+	}
+
 	if err != nil {
 		glog.V(100).Infof(
 			"Error initializing NodeFeatureDiscovery from alm-examples: %s", err.Error())
@@ -99,6 +105,12 @@ func Pull(apiClient *clients.Settings, name, namespace string) (*Builder, error)
 		},
 	}
 
+	if err := apiClient.AttachScheme(nfdv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add nodeFeatureDiscovery scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("NodeFeatureDiscovery name is empty")
 
@@ -111,11 +123,17 @@ func Pull(apiClient *clients.Settings, name, namespace string) (*Builder, error)
 		builder.errorMsg = "NodeFeatureDiscovery 'namespace' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("NodeFeatureDiscovery object %s doesn't exist in namespace %s", name, namespace)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -137,7 +155,7 @@ func (builder *Builder) Exists() bool {
 		glog.V(100).Infof("Failed to collect NodeFeatureDiscovery object due to %s", err.Error())
 	}
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Delete removes a NodeFeatureDiscovery.
@@ -0,0 +1,229 @@
+package nmstate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// EnactmentBuilder provides struct for the NodeNetworkConfigurationEnactment object containing connection to
+// the cluster. An enactment reports the result of applying a NodeNetworkConfigurationPolicy on a single node.
+type EnactmentBuilder struct {
+	// Created NodeNetworkConfigurationEnactment object on the cluster.
+	Object *nmstateV1alpha1.NodeNetworkConfigurationEnactment
+	// API client to interact with the cluster.
+	apiClient *clients.Settings
+	// errorMsg is processed before NodeNetworkConfigurationEnactment object is used.
+	errorMsg string
+}
+
+// EnactmentStatus provides struct summarizing the state of a NodeNetworkConfigurationEnactment.
+type EnactmentStatus struct {
+	// NodeName is the name of the node the enactment belongs to.
+	NodeName string
+	// Condition is the enactment condition currently set to True, empty if none is set yet.
+	Condition nmstateShared.ConditionType
+	// Reason of the active condition.
+	Reason nmstateShared.ConditionReason
+	// Message of the active condition, contains the nmstate error output when the enactment is failing.
+	Message string
+	// PolicyGeneration is the policy generation the enactment status belongs to.
+	PolicyGeneration int64
+}
+
+// NodeName returns the name of the node the NodeNetworkConfigurationEnactment belongs to.
+func (builder *EnactmentBuilder) NodeName() string {
+	if valid, _ := builder.validate(); !valid {
+		return ""
+	}
+
+	if nodeName, ok := builder.Object.Labels[nmstateShared.EnactmentNodeLabel]; ok {
+		return nodeName
+	}
+
+	// Enactments are named <node>.<policy>, used if the node label is missing.
+	policyName := builder.Object.Labels[nmstateShared.EnactmentPolicyLabel]
+
+	return strings.TrimSuffix(builder.Object.Name, "."+policyName)
+}
+
+// GetStatus returns the summarized status of the NodeNetworkConfigurationEnactment.
+func (builder *EnactmentBuilder) GetStatus() (EnactmentStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return EnactmentStatus{}, err
+	}
+
+	glog.V(100).Infof("Getting status of NodeNetworkConfigurationEnactment %s", builder.Object.Name)
+
+	status := EnactmentStatus{
+		NodeName:         builder.NodeName(),
+		PolicyGeneration: builder.Object.Status.PolicyGeneration,
+	}
+
+	for _, condition := range builder.Object.Status.Conditions {
+		if condition.Status == coreV1.ConditionTrue {
+			status.Condition = condition.Type
+			status.Reason = condition.Reason
+			status.Message = condition.Message
+
+			break
+		}
+	}
+
+	return status, nil
+}
+
+// IsFailed returns true if the NodeNetworkConfigurationEnactment is Failing or Aborted.
+func (builder *EnactmentBuilder) IsFailed() bool {
+	status, err := builder.GetStatus()
+	if err != nil {
+		return false
+	}
+
+	return status.Condition == nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing ||
+		status.Condition == nmstateShared.NodeNetworkConfigurationEnactmentConditionAborted
+}
+
+// ListEnactments returns the NodeNetworkConfigurationEnactments created for the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) ListEnactments() ([]*EnactmentBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	return ListEnactments(builder.apiClient, builder.Definition.Name)
+}
+
+// GetEnactmentStatuses returns the enactment status of the NodeNetworkConfigurationPolicy per node name.
+func (builder *PolicyBuilder) GetEnactmentStatuses() (map[string]EnactmentStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting enactment statuses of NodeNetworkConfigurationPolicy %s", builder.Definition.Name)
+
+	enactments, err := builder.ListEnactments()
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make(map[string]EnactmentStatus)
+
+	for _, enactment := range enactments {
+		status, err := enactment.GetStatus()
+		if err != nil {
+			return nil, err
+		}
+
+		statuses[status.NodeName] = status
+	}
+
+	return statuses, nil
+}
+
+// WaitUntilEnactmentsAvailable waits for the duration of the defined timeout or until the
+// NodeNetworkConfigurationPolicy is Available on every node matching its nodeSelector.
+// It returns immediately with an error when any enactment becomes Failing or Aborted.
+func (builder *PolicyBuilder) WaitUntilEnactmentsAvailable(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until NodeNetworkConfigurationPolicy %s is available "+
+		"on all matching nodes", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return fmt.Errorf("cannot wait for NodeNetworkConfigurationPolicy enactments because policy does not exist")
+	}
+
+	matchedNodes, err := builder.apiClient.CoreV1Interface.Nodes().List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.Set(builder.Definition.Spec.NodeSelector).String(),
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to list nodes matching NodeNetworkConfigurationPolicy %s: %w",
+			builder.Definition.Name, err)
+	}
+
+	if len(matchedNodes.Items) == 0 {
+		return fmt.Errorf("no nodes match the nodeSelector of NodeNetworkConfigurationPolicy %s",
+			builder.Definition.Name)
+	}
+
+	return wait.PollImmediate(retryInterval, timeout, func() (bool, error) {
+		policy, err := builder.Get()
+		if err != nil {
+			return false, nil
+		}
+
+		statuses, err := builder.GetEnactmentStatuses()
+		if err != nil {
+			return false, nil
+		}
+
+		allAvailable := true
+
+		for _, node := range matchedNodes.Items {
+			status, found := statuses[node.Name]
+
+			if found && status.PolicyGeneration == policy.Generation &&
+				(status.Condition == nmstateShared.NodeNetworkConfigurationEnactmentConditionFailing ||
+					status.Condition == nmstateShared.NodeNetworkConfigurationEnactmentConditionAborted) {
+				glog.V(100).Infof("NodeNetworkConfigurationPolicy %s is %s on node %s: %s",
+					builder.Definition.Name, status.Condition, node.Name, status.Message)
+
+				return false, fmt.Errorf("NodeNetworkConfigurationPolicy %s is %s on node %s: %s",
+					builder.Definition.Name, status.Condition, node.Name, status.Message)
+			}
+
+			if !found || status.PolicyGeneration != policy.Generation ||
+				status.Condition != nmstateShared.NodeNetworkConfigurationEnactmentConditionAvailable {
+				allAvailable = false
+			}
+		}
+
+		return allAvailable, nil
+	})
+}
+
+// validate will check that the builder and builder object are properly initialized before
+// accessing any member fields.
+func (builder *EnactmentBuilder) validate() (bool, error) {
+	resourceCRD := "NodeNetworkConfigurationEnactment"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Object == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
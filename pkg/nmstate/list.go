@@ -2,16 +2,26 @@ package nmstate
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/golang/glog"
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
 	nmstateV1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	nmstateV1alpha1 "github.com/nmstate/kubernetes-nmstate/api/v1alpha1"
 	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
 
 // ListPolicy returns a list of NodeNetworkConfigurationPolicy.
 func ListPolicy(apiClient *clients.Settings) ([]*PolicyBuilder, error) {
 	glog.V(100).Infof("Listing NodeNetworkConfigurationPolicy")
 
+	if err := apiClient.AttachScheme(nmstateV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NodeNetworkConfigurationPolicy scheme to client schemes")
+
+		return nil, err
+	}
+
 	policyList := &nmstateV1.NodeNetworkConfigurationPolicyList{}
 	err := apiClient.Client.List(context.Background(), policyList)
 
@@ -35,3 +45,43 @@ func ListPolicy(apiClient *clients.Settings) ([]*PolicyBuilder, error) {
 
 	return networkConfigurationPolicyObjects, nil
 }
+
+// ListEnactments returns a list of NodeNetworkConfigurationEnactments created for the given policy.
+func ListEnactments(apiClient *clients.Settings, policyName string) ([]*EnactmentBuilder, error) {
+	glog.V(100).Infof("Listing NodeNetworkConfigurationEnactments of policy %s", policyName)
+
+	if err := apiClient.AttachScheme(nmstateV1alpha1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NodeNetworkConfigurationEnactment scheme to client schemes")
+
+		return nil, err
+	}
+
+	if policyName == "" {
+		glog.V(100).Infof("NodeNetworkConfigurationEnactments 'policyName' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list NodeNetworkConfigurationEnactments, 'policyName' parameter is empty")
+	}
+
+	enactmentList := &nmstateV1alpha1.NodeNetworkConfigurationEnactmentList{}
+	err := apiClient.Client.List(context.Background(), enactmentList,
+		goclient.MatchingLabels{nmstateShared.EnactmentPolicyLabel: policyName})
+
+	if err != nil {
+		glog.V(100).Infof("Failed to list NodeNetworkConfigurationEnactments due to %s", err.Error())
+
+		return nil, err
+	}
+
+	var enactmentObjects []*EnactmentBuilder
+
+	for _, enactment := range enactmentList.Items {
+		copiedEnactment := enactment
+		enactmentBuilder := &EnactmentBuilder{
+			apiClient: apiClient,
+			Object:    &copiedEnactment}
+
+		enactmentObjects = append(enactmentObjects, enactmentBuilder)
+	}
+
+	return enactmentObjects, nil
+}
@@ -42,6 +42,12 @@ func NewBuilder(apiClient *clients.Settings, name string) *Builder {
 		},
 	}
 
+	if err := apiClient.AttachScheme(nmstateV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NMState scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add NMState scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the NMState is empty")
 
@@ -66,7 +72,7 @@ func (builder *Builder) Exists() bool {
 		glog.V(100).Infof("Failed to collect NMState object due to %s", err.Error())
 	}
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns NMState object if found.
@@ -173,17 +179,29 @@ func PullNMstate(apiClient *clients.Settings, name string) (*Builder, error) {
 		},
 	}
 
+	if err := apiClient.AttachScheme(nmstateV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NMState scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the NMState is empty")
 
 		builder.errorMsg = "NMState 'name' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("NMState object %s doesn't exist", name)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -50,7 +50,7 @@ func (builder *StateBuilder) Exists() bool {
 		glog.V(100).Infof("Failed to collect NodeNetworkState object due to %s", err.Error())
 	}
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get returns NodeNetworkState object if found.
@@ -192,16 +192,29 @@ func PullNodeNetworkState(apiClient *clients.Settings, name string) (*StateBuild
 		},
 	}
 
+	if err := apiClient.AttachScheme(nmstateV1alpha1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NodeNetworkState scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the NodeNetworkState is empty")
 
 		stateBuilder.errorMsg = "NodeNetworkState 'name' cannot be empty"
 	}
 
-	if !stateBuilder.Exists() {
+	object, err := stateBuilder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("NodeNetworkState oject %s doesn't exist", name)
 	}
 
+	stateBuilder.Object = object
+
 	return &stateBuilder, nil
 }
 
@@ -3,6 +3,7 @@ package nmstate
 import (
 	"context"
 	"fmt"
+	"net"
 	"time"
 
 	"gopkg.in/yaml.v2"
@@ -26,6 +27,8 @@ import (
 var (
 	// allowedBondModes represents all allowed modes for Bond interface.
 	allowedBondModes = []string{"balance-rr", "active-backup", "balance-xor", "broadcast", "802.3ad"}
+	// allowedMacVlanModes represents all allowed modes for MAC-VLAN interface.
+	allowedMacVlanModes = []string{"vepa", "bridge", "private", "passthru", "source"}
 )
 
 // AdditionalOptions additional options for pod object.
@@ -60,6 +63,12 @@ func NewPolicyBuilder(apiClient *clients.Settings, name string, nodeSelector map
 		},
 	}
 
+	if err := apiClient.AttachScheme(nmstateV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add NodeNetworkConfigurationPolicy scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add NodeNetworkConfigurationPolicy scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the NodeNetworkConfigurationPolicy is empty")
 
@@ -112,7 +121,7 @@ func (builder *PolicyBuilder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Create makes a NodeNetworkConfigurationPolicy in the cluster and stores the created object in struct.
@@ -229,6 +238,27 @@ func (builder *PolicyBuilder) WithInterfaceAndVFs(sriovInterface string, numberO
 	return builder.withInterface(newInterface)
 }
 
+// WithEthernetInterface adds an ethernet interface in up state to the NodeNetworkConfigurationPolicy,
+// so that it can be configured with WithStaticIP, WithDHCP, WithIPv6Autoconf or WithMTU.
+func (builder *PolicyBuilder) WithEthernetInterface(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ethernet interface %s",
+		builder.Definition.Name, interfaceName)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{Name: interfaceName, Type: "ethernet", State: "up"})
+}
+
 // WithBondInterface adds Bond interface configuration to the NodeNetworkConfigurationPolicy.
 func (builder *PolicyBuilder) WithBondInterface(slavePorts []string, bondName, mode string) *PolicyBuilder {
 	if valid, err := builder.validate(); !valid {
@@ -269,6 +299,438 @@ func (builder *PolicyBuilder) WithBondInterface(slavePorts []string, bondName, m
 	return builder.withInterface(newInterface)
 }
 
+// WithVlanInterface adds VLAN interface configuration to the NodeNetworkConfigurationPolicy.
+// The VLAN interface is named <baseInterface>.<vlanID>.
+func (builder *PolicyBuilder) WithVlanInterface(baseInterface string, vlanID uint16) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with VLAN interface: BaseInterface %s, VlanID %d",
+		builder.Definition.Name, baseInterface, vlanID)
+
+	if baseInterface == "" {
+		glog.V(100).Infof("The baseInterface can not be empty string")
+
+		builder.errorMsg = "The baseInterface is empty sting"
+	}
+
+	if vlanID < 1 || vlanID > 4094 {
+		glog.V(100).Infof("Invalid vlanID %d, allowed range is 1-4094", vlanID)
+
+		builder.errorMsg = "invalid vlanID parameter, allowed range is 1-4094"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	newInterface := NetworkInterface{
+		Name:  fmt.Sprintf("%s.%d", baseInterface, vlanID),
+		Type:  "vlan",
+		State: "up",
+		Vlan: Vlan{
+			BaseIface: baseInterface,
+			ID:        int(vlanID),
+		},
+	}
+
+	return builder.withInterface(newInterface)
+}
+
+// WithMacVlanInterface adds MAC-VLAN interface configuration to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithMacVlanInterface(baseInterface, macVlanName, mode string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with MAC-VLAN interface %s: "+
+		"BaseInterface %s, Mode %s", builder.Definition.Name, macVlanName, baseInterface, mode)
+
+	if !slices.Contains(allowedMacVlanModes, mode) {
+		glog.V(100).Infof("error to add MAC-VLAN mode %s, allowed modes are %v", mode, allowedMacVlanModes)
+
+		builder.errorMsg = "invalid MAC-VLAN mode parameter"
+	}
+
+	if baseInterface == "" {
+		glog.V(100).Infof("The baseInterface can not be empty string")
+
+		builder.errorMsg = "The baseInterface is empty sting"
+	}
+
+	if macVlanName == "" {
+		glog.V(100).Infof("The macVlanName can not be empty string")
+
+		builder.errorMsg = "The macVlanName is empty sting"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	newInterface := NetworkInterface{
+		Name:  macVlanName,
+		Type:  "mac-vlan",
+		State: "up",
+		MacVlan: MacVlan{
+			BaseIface: baseInterface,
+			Mode:      mode,
+		},
+	}
+
+	return builder.withInterface(newInterface)
+}
+
+// WithLinuxBridge adds linux-bridge interface configuration to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithLinuxBridge(bridgeName string, ports []string, stpEnabled bool) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with linux-bridge %s: Ports %v, STP %t",
+		builder.Definition.Name, bridgeName, ports, stpEnabled)
+
+	if bridgeName == "" {
+		glog.V(100).Infof("The bridgeName can not be empty string")
+
+		builder.errorMsg = "The bridgeName is empty sting"
+
+		return builder
+	}
+
+	newInterface := NetworkInterface{
+		Name:  bridgeName,
+		Type:  "linux-bridge",
+		State: "up",
+		Bridge: Bridge{
+			Options: BridgeOptions{STP: &STPOptions{Enabled: stpEnabled}},
+			Port:    bridgePorts(ports),
+		},
+	}
+
+	return builder.withInterface(newInterface)
+}
+
+// WithOVSBridge adds ovs-bridge interface configuration to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithOVSBridge(bridgeName string, ports []string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ovs-bridge %s: Ports %v",
+		builder.Definition.Name, bridgeName, ports)
+
+	if bridgeName == "" {
+		glog.V(100).Infof("The bridgeName can not be empty string")
+
+		builder.errorMsg = "The bridgeName is empty sting"
+
+		return builder
+	}
+
+	newInterface := NetworkInterface{
+		Name:  bridgeName,
+		Type:  "ovs-bridge",
+		State: "up",
+		Bridge: Bridge{
+			Port: bridgePorts(ports),
+		},
+	}
+
+	return builder.withInterface(newInterface)
+}
+
+// WithOVSInternalInterface adds ovs-interface configuration to the NodeNetworkConfigurationPolicy and
+// attaches it as a port to the given ovs-bridge, which has to be defined before.
+func (builder *PolicyBuilder) WithOVSInternalInterface(bridgeName, interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with ovs-interface %s on ovs-bridge %s",
+		builder.Definition.Name, interfaceName, bridgeName)
+
+	if bridgeName == "" {
+		glog.V(100).Infof("The bridgeName can not be empty string")
+
+		builder.errorMsg = "The bridgeName is empty sting"
+	}
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.withDesiredState(func(desiredState *DesiredState) {
+		for index := range desiredState.Interfaces {
+			if desiredState.Interfaces[index].Name == bridgeName &&
+				desiredState.Interfaces[index].Type == "ovs-bridge" {
+				desiredState.Interfaces[index].Bridge.Port = append(
+					desiredState.Interfaces[index].Bridge.Port, map[string]string{"name": interfaceName})
+
+				return
+			}
+		}
+
+		glog.V(100).Infof("The ovs-bridge %s is not defined in NodeNetworkConfigurationPolicy %s",
+			bridgeName, builder.Definition.Name)
+
+		builder.errorMsg = fmt.Sprintf("ovs-bridge %s is not defined, use WithOVSBridge first", bridgeName)
+	})
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withInterface(NetworkInterface{Name: interfaceName, Type: "ovs-interface", State: "up"})
+}
+
+// WithVRF adds VRF interface configuration to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithVRF(vrfName string, ports []string, routeTableID uint32) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with VRF %s: Ports %v, RouteTableID %d",
+		builder.Definition.Name, vrfName, ports, routeTableID)
+
+	if vrfName == "" {
+		glog.V(100).Infof("The vrfName can not be empty string")
+
+		builder.errorMsg = "The vrfName is empty sting"
+	}
+
+	if routeTableID == 0 {
+		glog.V(100).Infof("The routeTableID can not be zero")
+
+		builder.errorMsg = "The routeTableID can not be zero"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	newInterface := NetworkInterface{
+		Name:  vrfName,
+		Type:  "vrf",
+		State: "up",
+		Vrf: Vrf{
+			Port:         ports,
+			RouteTableID: int(routeTableID),
+		},
+	}
+
+	return builder.withInterface(newInterface)
+}
+
+// WithStaticIP adds a static IP address in CIDR notation to the given interface of the
+// NodeNetworkConfigurationPolicy. IPv4 and IPv6 addresses are placed in the matching address family.
+// The interface has to be defined in the policy before.
+func (builder *PolicyBuilder) WithStaticIP(interfaceName, ipAddress string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding static IP %s to interface %s in NodeNetworkConfigurationPolicy %s",
+		ipAddress, interfaceName, builder.Definition.Name)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+
+		return builder
+	}
+
+	parsedIP, ipNet, err := net.ParseCIDR(ipAddress)
+
+	if err != nil {
+		glog.V(100).Infof("The ipAddress %s is not a valid CIDR", ipAddress)
+
+		builder.errorMsg = fmt.Sprintf("invalid ipAddress parameter %s, CIDR notation expected", ipAddress)
+
+		return builder
+	}
+
+	prefixLen, _ := ipNet.Mask.Size()
+	address := IPAddress{IP: parsedIP.String(), PrefixLen: prefixLen}
+
+	return builder.withInterfaceSettings(interfaceName, func(networkInterface *NetworkInterface) {
+		if parsedIP.To4() != nil {
+			networkInterface.Ipv4 = withStaticAddress(networkInterface.Ipv4, address)
+
+			return
+		}
+
+		networkInterface.Ipv6 = withStaticAddress(networkInterface.Ipv6, address)
+	})
+}
+
+// WithDHCP enables IPv4 DHCP on the given interface of the NodeNetworkConfigurationPolicy.
+// The interface has to be defined in the policy before.
+func (builder *PolicyBuilder) WithDHCP(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Enabling DHCP on interface %s in NodeNetworkConfigurationPolicy %s",
+		interfaceName, builder.Definition.Name)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+
+		return builder
+	}
+
+	return builder.withInterfaceSettings(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.Ipv4 = &InterfaceIP{Enabled: true, Dhcp: true}
+	})
+}
+
+// WithIPv6Autoconf enables IPv6 DHCP and router advertisement based autoconfiguration on the given
+// interface of the NodeNetworkConfigurationPolicy. The interface has to be defined in the policy before.
+func (builder *PolicyBuilder) WithIPv6Autoconf(interfaceName string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Enabling IPv6 autoconf on interface %s in NodeNetworkConfigurationPolicy %s",
+		interfaceName, builder.Definition.Name)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+
+		return builder
+	}
+
+	return builder.withInterfaceSettings(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.Ipv6 = &InterfaceIP{Enabled: true, Dhcp: true, Autoconf: true}
+	})
+}
+
+// WithMTU defines the MTU of the given interface of the NodeNetworkConfigurationPolicy.
+// The interface has to be defined in the policy before.
+func (builder *PolicyBuilder) WithMTU(interfaceName string, mtu uint32) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting MTU %d on interface %s in NodeNetworkConfigurationPolicy %s",
+		mtu, interfaceName, builder.Definition.Name)
+
+	if interfaceName == "" {
+		glog.V(100).Infof("The interfaceName can not be empty string")
+
+		builder.errorMsg = "The interfaceName is empty sting"
+	}
+
+	if mtu == 0 {
+		glog.V(100).Infof("The mtu can not be zero")
+
+		builder.errorMsg = "The mtu can not be zero"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withInterfaceSettings(interfaceName, func(networkInterface *NetworkInterface) {
+		networkInterface.MTU = int(mtu)
+	})
+}
+
+// WithRoute adds a route to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithRoute(route Route) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding route %v to NodeNetworkConfigurationPolicy %s", route, builder.Definition.Name)
+
+	if _, _, err := net.ParseCIDR(route.Destination); err != nil {
+		glog.V(100).Infof("The route destination %s is not a valid CIDR", route.Destination)
+
+		builder.errorMsg = fmt.Sprintf("invalid route destination %s, CIDR notation expected", route.Destination)
+	}
+
+	if route.NextHopAddress == "" && route.NextHopInterface == "" && route.State != "absent" {
+		glog.V(100).Infof("The route has neither next-hop-address nor next-hop-interface")
+
+		builder.errorMsg = "route requires next-hop-address or next-hop-interface"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withDesiredState(func(desiredState *DesiredState) {
+		desiredState.Routes.Config = append(desiredState.Routes.Config, route)
+	})
+}
+
+// WithRouteRule adds a route rule to the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithRouteRule(rule RouteRule) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding route rule %v to NodeNetworkConfigurationPolicy %s", rule, builder.Definition.Name)
+
+	if rule.IPFrom == "" && rule.IPTo == "" {
+		glog.V(100).Infof("The route rule has neither ip-from nor ip-to")
+
+		builder.errorMsg = "route rule requires ip-from or ip-to"
+
+		return builder
+	}
+
+	return builder.withDesiredState(func(desiredState *DesiredState) {
+		desiredState.RouteRules.Config = append(desiredState.RouteRules.Config, rule)
+	})
+}
+
+// WithDNS defines the dns-resolver configuration of the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) WithDNS(servers, searchDomains []string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting DNS servers %v and search domains %v in NodeNetworkConfigurationPolicy %s",
+		servers, searchDomains, builder.Definition.Name)
+
+	for _, server := range servers {
+		if net.ParseIP(server) == nil {
+			glog.V(100).Infof("The DNS server %s is not a valid IP address", server)
+
+			builder.errorMsg = fmt.Sprintf("invalid DNS server parameter %s", server)
+		}
+	}
+
+	if len(servers) == 0 && len(searchDomains) == 0 {
+		glog.V(100).Infof("The DNS servers and search domains can not be both empty")
+
+		builder.errorMsg = "DNS servers and search domains are empty"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	return builder.withDesiredState(func(desiredState *DesiredState) {
+		desiredState.DNS.Config = DNSConfig{Server: servers, Search: searchDomains}
+	})
+}
+
 // WithOptions creates pod with generic mutation options.
 func (builder *PolicyBuilder) WithOptions(options ...AdditionalOptions) *PolicyBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -394,6 +856,57 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 	glog.V(100).Infof("Creating NodeNetworkConfigurationPolicy %s with network interface %s",
 		builder.Definition.Name, networkInterface.Name)
 
+	return builder.withDesiredState(func(desiredState *DesiredState) {
+		desiredState.Interfaces = append(desiredState.Interfaces, networkInterface)
+	})
+}
+
+// withInterfaceSettings applies the given mutation to the named interface of the NodeNetworkConfigurationPolicy.
+// The interface has to be defined in the desired state before, otherwise the builder reports an error.
+func (builder *PolicyBuilder) withInterfaceSettings(
+	interfaceName string, mutate func(networkInterface *NetworkInterface)) *PolicyBuilder {
+	var currentState DesiredState
+
+	if err := yaml.Unmarshal(builder.Definition.Spec.DesiredState.Raw, &currentState); err != nil {
+		glog.V(100).Infof("Failed Unmarshal DesiredState")
+
+		builder.errorMsg = "Failed Unmarshal DesiredState"
+
+		return builder
+	}
+
+	defined := false
+
+	for _, networkInterface := range currentState.Interfaces {
+		if networkInterface.Name == interfaceName {
+			defined = true
+
+			break
+		}
+	}
+
+	if !defined {
+		glog.V(100).Infof("The interface %s is not defined in NodeNetworkConfigurationPolicy %s",
+			interfaceName, builder.Definition.Name)
+
+		builder.errorMsg = fmt.Sprintf("interface %s is not defined", interfaceName)
+
+		return builder
+	}
+
+	return builder.withDesiredState(func(desiredState *DesiredState) {
+		for index := range desiredState.Interfaces {
+			if desiredState.Interfaces[index].Name == interfaceName {
+				mutate(&desiredState.Interfaces[index])
+
+				return
+			}
+		}
+	})
+}
+
+// withDesiredState applies the given mutation to the desired state of the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) withDesiredState(mutate func(desiredState *DesiredState)) *PolicyBuilder {
 	var CurrentState DesiredState
 
 	err := yaml.Unmarshal(builder.Definition.Spec.DesiredState.Raw, &CurrentState)
@@ -406,7 +919,7 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 		return builder
 	}
 
-	CurrentState.Interfaces = append(CurrentState.Interfaces, networkInterface)
+	mutate(&CurrentState)
 
 	desiredStateYaml, err := yaml.Marshal(CurrentState)
 
@@ -422,3 +935,28 @@ func (builder *PolicyBuilder) withInterface(networkInterface NetworkInterface) *
 
 	return builder
 }
+
+// bridgePorts converts interface names into the NMState bridge port list.
+func bridgePorts(ports []string) []map[string]string {
+	var bridgePortList []map[string]string
+
+	for _, port := range ports {
+		bridgePortList = append(bridgePortList, map[string]string{"name": port})
+	}
+
+	return bridgePortList
+}
+
+// withStaticAddress appends the address to the interface IP configuration and disables dynamic addressing.
+func withStaticAddress(interfaceIP *InterfaceIP, address IPAddress) *InterfaceIP {
+	if interfaceIP == nil {
+		interfaceIP = &InterfaceIP{}
+	}
+
+	interfaceIP.Enabled = true
+	interfaceIP.Dhcp = false
+	interfaceIP.Autoconf = false
+	interfaceIP.Address = append(interfaceIP.Address, address)
+
+	return interfaceIP
+}
@@ -0,0 +1,206 @@
+package nmstate
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	nmstateShared "github.com/nmstate/kubernetes-nmstate/api/shared"
+	nmstateV1 "github.com/nmstate/kubernetes-nmstate/api/v1"
+	"gopkg.in/yaml.v2"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const rollbackPolicySuffix = "-rollback"
+
+// rollbackState provides struct for the NMState desired state reverting a NodeNetworkConfigurationPolicy.
+// It is kept separate from DesiredState since total-vfs: 0 has to be rendered explicitly.
+type rollbackState struct {
+	Interfaces []rollbackInterface `yaml:"interfaces,omitempty"`
+	Routes     Routes              `yaml:"routes,omitempty"`
+	RouteRules RouteRules          `yaml:"route-rules,omitempty"`
+}
+
+type rollbackInterface struct {
+	Name     string            `yaml:"name"`
+	Type     string            `yaml:"type"`
+	State    string            `yaml:"state"`
+	Ethernet *rollbackEthernet `yaml:"ethernet,omitempty"`
+}
+
+type rollbackEthernet struct {
+	Sriov rollbackSriov `yaml:"sr-iov"`
+}
+
+type rollbackSriov struct {
+	TotalVfs int `yaml:"total-vfs"`
+}
+
+// DeleteWithRollback reverts the host network configuration applied by the NodeNetworkConfigurationPolicy
+// and removes it. The policy is deleted first so that it is no longer enforced, then a rollback policy marking
+// the policy's virtual interfaces, routes and route rules absent and resetting SR-IOV VFs to 0 is applied to the
+// same nodes and removed once it is available on all of them. A rollback policy left behind by an earlier run is
+// replaced, and the rollback policy is removed when it does not become available. Other settings of physical
+// ethernet interfaces and DNS configuration are not reverted.
+func (builder *PolicyBuilder) DeleteWithRollback(timeout time.Duration) (*PolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Reverting and deleting NodeNetworkConfigurationPolicy %s", builder.Definition.Name)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("NodeNetworkConfigurationPolicy cannot be reverted because it does not exist")
+	}
+
+	inverseState, err := builder.getRollbackState()
+	if err != nil {
+		return builder, err
+	}
+
+	if inverseState.isEmpty() {
+		glog.V(100).Infof("NodeNetworkConfigurationPolicy %s has nothing to revert", builder.Definition.Name)
+
+		return builder.Delete()
+	}
+
+	rollbackPolicy, err := builder.newRollbackPolicy(inverseState)
+	if err != nil {
+		return builder, err
+	}
+
+	if _, err = builder.Delete(); err != nil {
+		return builder, err
+	}
+
+	if err = rollbackPolicy.apply(); err != nil {
+		return builder, err
+	}
+
+	if err = rollbackPolicy.WaitUntilEnactmentsAvailable(timeout); err != nil {
+		// The rollback policy would keep being enforced on the nodes, so it must not be left behind.
+		if _, deleteErr := rollbackPolicy.Delete(); deleteErr != nil {
+			glog.V(100).Infof("Failed to delete rollback NodeNetworkConfigurationPolicy %s: %v",
+				rollbackPolicy.Definition.Name, deleteErr)
+
+			return builder, fmt.Errorf("rollback NodeNetworkConfigurationPolicy %s failed: %w, and its removal failed: %v",
+				rollbackPolicy.Definition.Name, err, deleteErr)
+		}
+
+		return builder, fmt.Errorf("rollback NodeNetworkConfigurationPolicy %s failed: %w",
+			rollbackPolicy.Definition.Name, err)
+	}
+
+	if _, err = rollbackPolicy.Delete(); err != nil {
+		return builder, err
+	}
+
+	return builder, nil
+}
+
+// apply creates the rollback policy, or updates a rollback policy left behind by an earlier failed run
+// so that the current rollback state is applied instead of the stale one.
+func (builder *PolicyBuilder) apply() error {
+	if !builder.Exists() || builder.Object == nil {
+		glog.V(100).Infof("Applying rollback NodeNetworkConfigurationPolicy %s", builder.Definition.Name)
+
+		if _, err := builder.Create(); err != nil {
+			return fmt.Errorf("failed to create rollback NodeNetworkConfigurationPolicy %s: %w",
+				builder.Definition.Name, err)
+		}
+
+		return nil
+	}
+
+	glog.V(100).Infof("Updating existing rollback NodeNetworkConfigurationPolicy %s", builder.Definition.Name)
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	if _, err := builder.Update(false); err != nil {
+		return fmt.Errorf("failed to update existing rollback NodeNetworkConfigurationPolicy %s: %w",
+			builder.Definition.Name, err)
+	}
+
+	return nil
+}
+
+// getRollbackState derives the desired state reverting the NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) getRollbackState() (rollbackState, error) {
+	var (
+		currentState DesiredState
+		inverseState rollbackState
+	)
+
+	err := yaml.Unmarshal(builder.Definition.Spec.DesiredState.Raw, &currentState)
+	if err != nil {
+		return inverseState, fmt.Errorf("failed to Unmarshal DesiredState of NodeNetworkConfigurationPolicy %s: %w",
+			builder.Definition.Name, err)
+	}
+
+	for _, networkInterface := range currentState.Interfaces {
+		if networkInterface.State == "absent" {
+			continue
+		}
+
+		if networkInterface.Type == "ethernet" {
+			if networkInterface.Ethernet.Sriov.TotalVfs > 0 {
+				inverseState.Interfaces = append(inverseState.Interfaces, rollbackInterface{
+					Name:     networkInterface.Name,
+					Type:     networkInterface.Type,
+					State:    "up",
+					Ethernet: &rollbackEthernet{Sriov: rollbackSriov{TotalVfs: 0}},
+				})
+			}
+
+			continue
+		}
+
+		inverseState.Interfaces = append(inverseState.Interfaces, rollbackInterface{
+			Name:  networkInterface.Name,
+			Type:  networkInterface.Type,
+			State: "absent",
+		})
+	}
+
+	for _, route := range currentState.Routes.Config {
+		if route.State != "absent" {
+			route.State = "absent"
+			inverseState.Routes.Config = append(inverseState.Routes.Config, route)
+		}
+	}
+
+	for _, rule := range currentState.RouteRules.Config {
+		if rule.State != "absent" {
+			rule.State = "absent"
+			inverseState.RouteRules.Config = append(inverseState.RouteRules.Config, rule)
+		}
+	}
+
+	return inverseState, nil
+}
+
+// newRollbackPolicy returns a PolicyBuilder applying the given rollback state to the nodes of the
+// NodeNetworkConfigurationPolicy.
+func (builder *PolicyBuilder) newRollbackPolicy(inverseState rollbackState) (*PolicyBuilder, error) {
+	inverseStateYaml, err := yaml.Marshal(inverseState)
+	if err != nil {
+		return nil, fmt.Errorf("failed to Marshal rollback desired state: %w", err)
+	}
+
+	return &PolicyBuilder{
+		apiClient: builder.apiClient,
+		Definition: &nmstateV1.NodeNetworkConfigurationPolicy{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name: builder.Definition.Name + rollbackPolicySuffix,
+			},
+			Spec: nmstateShared.NodeNetworkConfigurationPolicySpec{
+				NodeSelector: builder.Definition.Spec.NodeSelector,
+				DesiredState: nmstateShared.NewState(string(inverseStateYaml)),
+			},
+		},
+	}, nil
+}
+
+func (state rollbackState) isEmpty() bool {
+	return len(state.Interfaces) == 0 && len(state.Routes.Config) == 0 && len(state.RouteRules.Config) == 0
+}
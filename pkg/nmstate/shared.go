@@ -3,6 +3,9 @@ package nmstate
 // DesiredState provides struct for the NMState desired state object containing all NMState configuration.
 type DesiredState struct {
 	Interfaces []NetworkInterface `yaml:"interfaces,omitempty"`
+	Routes     Routes             `yaml:"routes,omitempty"`
+	RouteRules RouteRules         `yaml:"route-rules,omitempty"`
+	DNS        DNSResolver        `yaml:"dns-resolver,omitempty"`
 }
 
 // NetworkInterface provides struct for the NMState interface state object containing interface information.
@@ -10,10 +13,33 @@ type NetworkInterface struct {
 	Name            string          `yaml:"name"`
 	Type            string          `yaml:"type"`
 	State           string          `yaml:"state"`
+	MTU             int             `yaml:"mtu,omitempty"`
+	MacAddress      string          `yaml:"mac-address,omitempty"`
+	Ipv4            *InterfaceIP    `yaml:"ipv4,omitempty"`
+	Ipv6            *InterfaceIP    `yaml:"ipv6,omitempty"`
 	Ethernet        Ethernet        `yaml:"ethernet,omitempty"`
 	Bridge          Bridge          `yaml:"bridge,omitempty"`
 	LinkAggregation LinkAggregation `yaml:"link-aggregation,omitempty"`
 	Vlan            Vlan            `yaml:"vlan,omitempty"`
+	Vrf             Vrf             `yaml:"vrf,omitempty"`
+	MacVlan         MacVlan         `yaml:"mac-vlan,omitempty"`
+}
+
+// InterfaceIP provides struct for the NMState Interface ipv4/ipv6 state object containing interface addressing.
+type InterfaceIP struct {
+	Enabled     bool        `yaml:"enabled"`
+	Dhcp        bool        `yaml:"dhcp,omitempty"`
+	Autoconf    bool        `yaml:"autoconf,omitempty"`
+	AutoDNS     *bool       `yaml:"auto-dns,omitempty"`
+	AutoGateway *bool       `yaml:"auto-gateway,omitempty"`
+	AutoRoutes  *bool       `yaml:"auto-routes,omitempty"`
+	Address     []IPAddress `yaml:"address,omitempty"`
+}
+
+// IPAddress provides struct for the NMState Interface IP address object.
+type IPAddress struct {
+	IP        string `yaml:"ip"`
+	PrefixLen int    `yaml:"prefix-length"`
 }
 
 // Ethernet provides struct for the NMState Interface Ethernet state object containing interface Ethernet information.
@@ -43,7 +69,34 @@ type Vf struct {
 // Bridge provides struct for the NMState Interface Ethernet Bridge state object
 // containing interface Bridge information.
 type Bridge struct {
-	Port []map[string]string `yaml:"port,omitempty"`
+	Options BridgeOptions       `yaml:"options,omitempty"`
+	Port    []map[string]string `yaml:"port,omitempty"`
+}
+
+// BridgeOptions provides struct for the NMState linux-bridge and ovs-bridge options.
+type BridgeOptions struct {
+	STP                 *STPOptions `yaml:"stp,omitempty"`
+	McastSnoopingEnable bool        `yaml:"mcast-snooping-enable,omitempty"`
+}
+
+// STPOptions provides struct for the NMState bridge spanning tree protocol options.
+type STPOptions struct {
+	Enabled bool `yaml:"enabled"`
+}
+
+// UnmarshalYAML accepts both the STP object format and the legacy boolean format reported for ovs bridges.
+func (stp *STPOptions) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var enabled bool
+
+	if err := unmarshal(&enabled); err == nil {
+		stp.Enabled = enabled
+
+		return nil
+	}
+
+	type rawSTPOptions STPOptions
+
+	return unmarshal((*rawSTPOptions)(stp))
 }
 
 // LinkAggregation provides struct for the NMState Interface Ethernet LinkAggregation state object
@@ -67,3 +120,58 @@ type Vlan struct {
 	BaseIface string `yaml:"base-iface"`
 	ID        int    `yaml:"id"`
 }
+
+// Vrf provides struct for the NMState Interface VRF state object containing interface VRF information.
+type Vrf struct {
+	Port         []string `yaml:"port,omitempty"`
+	RouteTableID int      `yaml:"route-table-id,omitempty"`
+}
+
+// MacVlan provides struct for the NMState Interface MAC-VLAN state object containing interface MAC-VLAN information.
+type MacVlan struct {
+	BaseIface   string `yaml:"base-iface,omitempty"`
+	Mode        string `yaml:"mode,omitempty"`
+	Promiscuous bool   `yaml:"promiscuous,omitempty"`
+}
+
+// Routes provides struct for the NMState routes state object.
+type Routes struct {
+	Config  []Route `yaml:"config,omitempty"`
+	Running []Route `yaml:"running,omitempty"`
+}
+
+// Route provides struct for the NMState route object.
+type Route struct {
+	Destination      string `yaml:"destination"`
+	NextHopAddress   string `yaml:"next-hop-address,omitempty"`
+	NextHopInterface string `yaml:"next-hop-interface,omitempty"`
+	Metric           int    `yaml:"metric,omitempty"`
+	TableID          int    `yaml:"table-id,omitempty"`
+	State            string `yaml:"state,omitempty"`
+}
+
+// RouteRules provides struct for the NMState route-rules state object.
+type RouteRules struct {
+	Config []RouteRule `yaml:"config,omitempty"`
+}
+
+// RouteRule provides struct for the NMState route rule object.
+type RouteRule struct {
+	IPFrom     string `yaml:"ip-from,omitempty"`
+	IPTo       string `yaml:"ip-to,omitempty"`
+	Priority   int    `yaml:"priority,omitempty"`
+	RouteTable int    `yaml:"route-table,omitempty"`
+	State      string `yaml:"state,omitempty"`
+}
+
+// DNSResolver provides struct for the NMState dns-resolver state object.
+type DNSResolver struct {
+	Config  DNSConfig `yaml:"config,omitempty"`
+	Running DNSConfig `yaml:"running,omitempty"`
+}
+
+// DNSConfig provides struct for the NMState dns-resolver configuration.
+type DNSConfig struct {
+	Server []string `yaml:"server,omitempty"`
+	Search []string `yaml:"search,omitempty"`
+}
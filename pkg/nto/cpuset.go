@@ -0,0 +1,88 @@
+package nto //nolint:misspell
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+	"k8s.io/kubernetes/pkg/kubelet/cm/cpuset"
+)
+
+// CPUSet provides a struct for a set of logical CPU ids as used by the PerformanceProfile CPU fields.
+type CPUSet struct {
+	cpus cpuset.CPUSet
+}
+
+// NewCPUSet returns a new CPUSet containing the given CPU ids.
+func NewCPUSet(cpus ...int) CPUSet {
+	return CPUSet{cpus: cpuset.NewCPUSet(cpus...)}
+}
+
+// ParseCPUSet parses a CPU list in linux cpuset format (e.g. "0-3,8,10-11") into a CPUSet.
+func ParseCPUSet(cpus string) (CPUSet, error) {
+	glog.V(100).Infof("Parsing cpuset %s", cpus)
+
+	parsedCPUs, err := cpuset.Parse(cpus)
+	if err != nil {
+		glog.V(100).Infof("Failed to parse cpuset %s due to %s", cpus, err.Error())
+
+		return CPUSet{}, fmt.Errorf("failed to parse cpuset %q: %w", cpus, err)
+	}
+
+	return CPUSet{cpus: parsedCPUs}, nil
+}
+
+// String returns the CPUSet in linux cpuset format, e.g. "0-3,8".
+func (set CPUSet) String() string {
+	return set.cpus.String()
+}
+
+// Size returns the number of CPUs in the CPUSet.
+func (set CPUSet) Size() int {
+	return set.cpus.Size()
+}
+
+// IsEmpty returns true if the CPUSet contains no CPUs.
+func (set CPUSet) IsEmpty() bool {
+	return set.cpus.IsEmpty()
+}
+
+// Contains returns true if the CPUSet contains the given CPU id.
+func (set CPUSet) Contains(cpu int) bool {
+	return set.cpus.Contains(cpu)
+}
+
+// Equals returns true if both CPUSets contain exactly the same CPUs.
+func (set CPUSet) Equals(other CPUSet) bool {
+	return set.cpus.Equals(other.cpus)
+}
+
+// IsSubsetOf returns true if every CPU of the CPUSet is also present in the other CPUSet.
+func (set CPUSet) IsSubsetOf(other CPUSet) bool {
+	return set.cpus.IsSubsetOf(other.cpus)
+}
+
+// List returns the sorted CPU ids of the CPUSet.
+func (set CPUSet) List() []int {
+	return set.cpus.ToSlice()
+}
+
+// Union returns a new CPUSet containing the CPUs of the CPUSet and all the given CPUSets.
+func (set CPUSet) Union(others ...CPUSet) CPUSet {
+	result := set.cpus
+
+	for _, other := range others {
+		result = result.Union(other.cpus)
+	}
+
+	return CPUSet{cpus: result}
+}
+
+// Intersection returns a new CPUSet containing only the CPUs present in both CPUSets.
+func (set CPUSet) Intersection(other CPUSet) CPUSet {
+	return CPUSet{cpus: set.cpus.Intersection(other.cpus)}
+}
+
+// Difference returns a new CPUSet containing the CPUs of the CPUSet that are not present in the other CPUSet.
+func (set CPUSet) Difference(other CPUSet) CPUSet {
+	return CPUSet{cpus: set.cpus.Difference(other.cpus)}
+}
@@ -12,6 +12,12 @@ import (
 func ListProfiles(apiClient *clients.Settings) ([]*Builder, error) {
 	glog.V(100).Infof("Listing PerformanceProfiles on cluster")
 
+	if err := apiClient.AttachScheme(v2.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add PerformanceProfile scheme to client schemes")
+
+		return nil, err
+	}
+
 	var performanceProfiles v2.PerformanceProfileList
 	err := apiClient.List(context.TODO(), &performanceProfiles)
 
@@ -25,6 +25,8 @@ type Builder struct {
 	errorMsg string
 	// api client to interact with the cluster.
 	apiClient *clients.Settings
+	// CPU topology of the target nodes, used to validate cpu sets before creation.
+	cpuTopology *CPUTopology
 }
 
 // NewBuilder creates a new instance of Builder.
@@ -53,6 +55,12 @@ func NewBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(v2.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add PerformanceProfile scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add PerformanceProfile scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the PerformanceProfile is empty")
 
@@ -71,6 +79,14 @@ func NewBuilder(
 		builder.errorMsg = "PerformanceProfile's 'cpuReserved' is empty"
 	}
 
+	if cpuIsolated != "" && cpuReserved != "" {
+		if _, _, err := parseCPUSets(cpuReserved, cpuIsolated); err != nil {
+			glog.V(100).Infof("Invalid cpu sets for the PerformanceProfile: %s", err.Error())
+
+			builder.errorMsg = fmt.Sprintf("PerformanceProfile's cpu sets are invalid: %s", err.Error())
+		}
+	}
+
 	if len(nodeSelector) == 0 {
 		glog.V(100).Infof("NodeSelector of the PerformanceProfile is empty")
 
@@ -93,17 +109,29 @@ func Pull(apiClient *clients.Settings, name string) (*Builder, error) {
 		},
 	}
 
+	if err := apiClient.AttachScheme(v2.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add PerformanceProfile scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the PerformanceProfile is empty")
 
 		builder.errorMsg = "PerformanceProfile 'name' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("PerformanceProfile object %s doesn't exist", name)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -244,6 +272,68 @@ func (builder *Builder) WithWorkloadHints(rtHint, perPodPowerMgmtHint, highPower
 	return builder
 }
 
+// WithCPUTopology defines the CPU topology of the target nodes. The reserved and isolated cpu sets are
+// validated against it before the PerformanceProfile is created or updated.
+func (builder *Builder) WithCPUTopology(topology *CPUTopology) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding CPU topology to PerformanceProfile %s", builder.Definition.Name)
+
+	if topology == nil || len(topology.CPUs) == 0 {
+		glog.V(100).Infof("'topology' argument cannot be empty")
+
+		builder.errorMsg = "'topology' argument cannot be empty"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.cpuTopology = topology
+
+	return builder
+}
+
+// WithTopologyAwareCPUs redefines the reserved and isolated cpu sets in the PerformanceProfile using
+// the given CPU topology. Reserved CPUs are allocated as whole cores, keeping siblings together.
+func (builder *Builder) WithTopologyAwareCPUs(topology *CPUTopology, reservedCount int) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining %d topology aware reserved CPUs in PerformanceProfile %s",
+		reservedCount, builder.Definition.Name)
+
+	builder.WithCPUTopology(topology)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	reserved, isolated, err := topology.ProposeReservedIsolated(reservedCount)
+	if err != nil {
+		glog.V(100).Infof("Failed to propose cpu sets: %s", err.Error())
+
+		builder.errorMsg = err.Error()
+
+		return builder
+	}
+
+	reservedCPUSet := v2.CPUSet(reserved.String())
+	isolatedCPUSet := v2.CPUSet(isolated.String())
+
+	if builder.Definition.Spec.CPU == nil {
+		builder.Definition.Spec.CPU = &v2.CPU{}
+	}
+
+	builder.Definition.Spec.CPU.Reserved = &reservedCPUSet
+	builder.Definition.Spec.CPU.Isolated = &isolatedCPUSet
+
+	return builder
+}
+
 // Create the PerformanceProfile in the cluster and store the created object in Object.
 func (builder *Builder) Create() (*Builder, error) {
 	if valid, err := builder.validate(); !valid {
@@ -252,6 +342,10 @@ func (builder *Builder) Create() (*Builder, error) {
 
 	glog.V(100).Infof("Creating PerformanceProfile %s ", builder.Definition.Name)
 
+	if err := builder.validateCPUTopology(); err != nil {
+		return builder, err
+	}
+
 	var err error
 	if !builder.Exists() {
 		err = builder.apiClient.Create(context.TODO(), builder.Definition)
@@ -266,6 +360,55 @@ func (builder *Builder) Create() (*Builder, error) {
 	return builder, err
 }
 
+// Update renews the PerformanceProfile in the cluster and stores the updated object in Object.
+func (builder *Builder) Update(force bool) (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating PerformanceProfile %s", builder.Definition.Name)
+
+	if err := builder.validateCPUTopology(); err != nil {
+		return builder, err
+	}
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update PerformanceProfile, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the PerformanceProfile object %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name)
+
+			builder, err := builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the PerformanceProfile object %s due to error in delete function",
+					builder.Definition.Name)
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return builder, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
 // Exists checks whether the given PerformanceProfile exists.
 func (builder *Builder) Exists() bool {
 	if valid, _ := builder.validate(); !valid {
@@ -277,7 +420,7 @@ func (builder *Builder) Exists() bool {
 	var err error
 	builder.Object, err = builder.Get()
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Get fetches the defined PerformanceProfile from the cluster.
@@ -324,6 +467,55 @@ func (builder *Builder) Delete() (*Builder, error) {
 	return builder, err
 }
 
+// validateCPUTopology checks the reserved, isolated and offlined cpu sets against the CPU topology if it was defined.
+func (builder *Builder) validateCPUTopology() error {
+	if builder.cpuTopology == nil || builder.Definition.Spec.CPU == nil ||
+		builder.Definition.Spec.CPU.Reserved == nil || builder.Definition.Spec.CPU.Isolated == nil {
+		return nil
+	}
+
+	reserved, isolated, err := parseCPUSets(
+		string(*builder.Definition.Spec.CPU.Reserved), string(*builder.Definition.Spec.CPU.Isolated))
+	if err != nil {
+		return err
+	}
+
+	var offlined CPUSet
+
+	if builder.Definition.Spec.CPU.Offlined != nil {
+		offlined, err = ParseCPUSet(string(*builder.Definition.Spec.CPU.Offlined))
+		if err != nil {
+			return err
+		}
+	}
+
+	err = builder.cpuTopology.Validate(reserved, isolated, offlined)
+	if err != nil {
+		glog.V(100).Infof("PerformanceProfile %s cpu sets do not match the CPU topology: %s",
+			builder.Definition.Name, err.Error())
+
+		return fmt.Errorf("PerformanceProfile %s cpu sets do not match the CPU topology: %w",
+			builder.Definition.Name, err)
+	}
+
+	return nil
+}
+
+// parseCPUSets parses reserved and isolated cpu sets and checks that they are disjoint.
+func parseCPUSets(cpuReserved, cpuIsolated string) (reserved, isolated CPUSet, err error) {
+	reserved, err = ParseCPUSet(cpuReserved)
+	if err != nil {
+		return CPUSet{}, CPUSet{}, err
+	}
+
+	isolated, err = ParseCPUSet(cpuIsolated)
+	if err != nil {
+		return CPUSet{}, CPUSet{}, err
+	}
+
+	return reserved, isolated, validateCPUSetsDisjoint(reserved, isolated)
+}
+
 // validate will check that the builder and builder definition are properly initialized before
 // accessing any member fields.
 func (builder *Builder) validate() (bool, error) {
@@ -0,0 +1,207 @@
+package nto //nolint:misspell
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/pod"
+)
+
+// lscpuTopologyCmd prints one line per logical CPU in the form cpu,core,socket,node.
+const lscpuTopologyCmd = "lscpu -p=CPU,CORE,SOCKET,NODE"
+
+// CPUInfo provides a struct describing the placement of a single logical CPU.
+type CPUInfo struct {
+	CPU      int
+	Core     int
+	Socket   int
+	NUMANode int
+}
+
+// CPUTopology provides a struct for the logical CPU layout of a node.
+type CPUTopology struct {
+	CPUs []CPUInfo
+}
+
+// GetCPUTopology reads the CPU topology of the node where the given pod runs.
+// The pod has to be running and its default container must provide the lscpu binary.
+func GetCPUTopology(execPod *pod.Builder) (*CPUTopology, error) {
+	if execPod == nil || execPod.Object == nil {
+		glog.V(100).Infof("The pod used to collect the CPU topology is not running")
+
+		return nil, fmt.Errorf("cannot collect CPU topology: pod is not running")
+	}
+
+	glog.V(100).Infof("Collecting CPU topology of node %s using pod %s in namespace %s",
+		execPod.Object.Spec.NodeName, execPod.Object.Name, execPod.Object.Namespace)
+
+	output, err := execPod.ExecCommand([]string{"/bin/sh", "-c", lscpuTopologyCmd})
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect CPU topology from pod %s: %w", execPod.Object.Name, err)
+	}
+
+	return NewCPUTopology(output.String())
+}
+
+// NewCPUTopology parses the output of 'lscpu -p=CPU,CORE,SOCKET,NODE' into a CPUTopology.
+func NewCPUTopology(lscpuOutput string) (*CPUTopology, error) {
+	glog.V(100).Infof("Parsing CPU topology from lscpu output")
+
+	topology := &CPUTopology{}
+
+	for _, line := range strings.Split(lscpuOutput, "\n") {
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		fields := strings.Split(line, ",")
+		if len(fields) != 4 {
+			return nil, fmt.Errorf("unexpected lscpu line %q: expected 4 comma separated fields", line)
+		}
+
+		values := make([]int, len(fields))
+
+		for index, field := range fields {
+			// NUMA node is empty on systems without NUMA support.
+			if field == "" {
+				continue
+			}
+
+			value, err := strconv.Atoi(field)
+			if err != nil {
+				return nil, fmt.Errorf("unexpected lscpu line %q: %w", line, err)
+			}
+
+			values[index] = value
+		}
+
+		topology.CPUs = append(topology.CPUs, CPUInfo{
+			CPU: values[0], Core: values[1], Socket: values[2], NUMANode: values[3]})
+	}
+
+	if len(topology.CPUs) == 0 {
+		return nil, fmt.Errorf("no CPUs found in lscpu output")
+	}
+
+	return topology, nil
+}
+
+// AllCPUs returns a CPUSet containing every logical CPU of the topology.
+func (topology *CPUTopology) AllCPUs() CPUSet {
+	var cpus []int
+
+	for _, cpuInfo := range topology.CPUs {
+		cpus = append(cpus, cpuInfo.CPU)
+	}
+
+	return NewCPUSet(cpus...)
+}
+
+// Siblings returns the CPUSet of all logical CPUs sharing the physical core of the given CPU, including itself.
+func (topology *CPUTopology) Siblings(cpu int) CPUSet {
+	var siblings []int
+
+	for _, cpuInfo := range topology.CPUs {
+		if cpuInfo.CPU != cpu {
+			continue
+		}
+
+		for _, candidate := range topology.CPUs {
+			if candidate.Socket == cpuInfo.Socket && candidate.Core == cpuInfo.Core {
+				siblings = append(siblings, candidate.CPU)
+			}
+		}
+	}
+
+	return NewCPUSet(siblings...)
+}
+
+// ProposeReservedIsolated splits the topology into reserved and isolated CPUSets.
+// Reserved CPUs are taken as whole physical cores, so hyper-threading siblings are never split between the sets.
+// Cores are picked in order of NUMA node, socket and core id.
+func (topology *CPUTopology) ProposeReservedIsolated(reservedCount int) (reserved, isolated CPUSet, err error) {
+	glog.V(100).Infof("Proposing %d reserved CPUs from topology with %d CPUs", reservedCount, len(topology.CPUs))
+
+	allCPUs := topology.AllCPUs()
+
+	if reservedCount <= 0 || reservedCount >= allCPUs.Size() {
+		return CPUSet{}, CPUSet{}, fmt.Errorf(
+			"reserved CPU count must be between 1 and %d, got %d", allCPUs.Size()-1, reservedCount)
+	}
+
+	cpuInfos := make([]CPUInfo, len(topology.CPUs))
+	copy(cpuInfos, topology.CPUs)
+
+	sort.SliceStable(cpuInfos, func(i, j int) bool {
+		if cpuInfos[i].NUMANode != cpuInfos[j].NUMANode {
+			return cpuInfos[i].NUMANode < cpuInfos[j].NUMANode
+		}
+
+		if cpuInfos[i].Socket != cpuInfos[j].Socket {
+			return cpuInfos[i].Socket < cpuInfos[j].Socket
+		}
+
+		if cpuInfos[i].Core != cpuInfos[j].Core {
+			return cpuInfos[i].Core < cpuInfos[j].Core
+		}
+
+		return cpuInfos[i].CPU < cpuInfos[j].CPU
+	})
+
+	for _, cpuInfo := range cpuInfos {
+		if reserved.Size() >= reservedCount {
+			break
+		}
+
+		reserved = reserved.Union(topology.Siblings(cpuInfo.CPU))
+	}
+
+	if reserved.Size() != reservedCount {
+		return CPUSet{}, CPUSet{}, fmt.Errorf(
+			"reserved CPU count %d cannot be satisfied without splitting core siblings, closest is %d",
+			reservedCount, reserved.Size())
+	}
+
+	return reserved, allCPUs.Difference(reserved), nil
+}
+
+// Validate checks that reserved and isolated CPUSets are not empty, that reserved, isolated and offlined CPUSets
+// do not overlap and that all of them exist in the topology. CPUs in none of the sets stay shared by the node.
+func (topology *CPUTopology) Validate(reserved, isolated, offlined CPUSet) error {
+	if err := validateCPUSetsDisjoint(reserved, isolated); err != nil {
+		return err
+	}
+
+	if overlap := offlined.Intersection(reserved.Union(isolated)); !overlap.IsEmpty() {
+		return fmt.Errorf("offlined cpuset overlaps with reserved or isolated cpusets on CPUs %s", overlap.String())
+	}
+
+	configured := reserved.Union(isolated).Union(offlined)
+
+	if unknown := configured.Difference(topology.AllCPUs()); !unknown.IsEmpty() {
+		return fmt.Errorf("CPUs %s are not present on the node", unknown.String())
+	}
+
+	return nil
+}
+
+func validateCPUSetsDisjoint(reserved, isolated CPUSet) error {
+	if reserved.IsEmpty() {
+		return fmt.Errorf("reserved cpuset is empty")
+	}
+
+	if isolated.IsEmpty() {
+		return fmt.Errorf("isolated cpuset is empty")
+	}
+
+	if overlap := reserved.Intersection(isolated); !overlap.IsEmpty() {
+		return fmt.Errorf("reserved and isolated cpusets overlap on CPUs %s", overlap.String())
+	}
+
+	return nil
+}
@@ -0,0 +1,440 @@
+package nto //nolint:misspell
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TunedBuilder provides a struct for Tuned object from the cluster and a Tuned definition.
+type TunedBuilder struct {
+	// Tuned definition, used to create the Tuned object.
+	Definition *tunedv1.Tuned
+	// Created Tuned object.
+	Object *tunedv1.Tuned
+	// Used to store latest error message upon defining or mutating Tuned definition.
+	errorMsg string
+	// api client to interact with the cluster.
+	apiClient *clients.Settings
+}
+
+// TunedAdditionalOptions additional options for Tuned object.
+type TunedAdditionalOptions func(builder *TunedBuilder) (*TunedBuilder, error)
+
+// NewTunedBuilder creates a new instance of TunedBuilder.
+func NewTunedBuilder(apiClient *clients.Settings, name, nsname string) *TunedBuilder {
+	glog.V(100).Infof(
+		"Initializing new Tuned structure with the following params: name: %s, namespace: %s", name, nsname)
+
+	builder := &TunedBuilder{
+		apiClient: apiClient,
+		Definition: &tunedv1.Tuned{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(tunedv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add tuned scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add tuned scheme to client schemes: %v", err)
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the Tuned is empty")
+
+		builder.errorMsg = "Tuned's name is empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the Tuned is empty")
+
+		builder.errorMsg = "Tuned's namespace is empty"
+	}
+
+	return builder
+}
+
+// PullTuned pulls existing Tuned from cluster.
+func PullTuned(apiClient *clients.Settings, name, nsname string) (*TunedBuilder, error) {
+	glog.V(100).Infof("Pulling existing Tuned name %s in namespace %s from cluster", name, nsname)
+
+	builder := TunedBuilder{
+		apiClient: apiClient,
+		Definition: &tunedv1.Tuned{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(tunedv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add tuned scheme to client schemes")
+
+		return nil, err
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the Tuned is empty")
+
+		builder.errorMsg = "Tuned 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the Tuned is empty")
+
+		builder.errorMsg = "Tuned 'namespace' cannot be empty"
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("tuned object %s doesn't exist in namespace %s", name, nsname)
+	}
+
+	builder.Object = object
+	builder.Definition = object
+
+	return &builder, nil
+}
+
+// WithProfile adds a custom TuneD profile with the given name and content to the Tuned.
+func (builder *TunedBuilder) WithProfile(profileName, profileData string) *TunedBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding profile %s to Tuned %s in namespace %s",
+		profileName, builder.Definition.Name, builder.Definition.Namespace)
+
+	if profileName == "" {
+		glog.V(100).Infof("'profileName' argument cannot be empty")
+
+		builder.errorMsg = "'profileName' argument cannot be empty"
+	}
+
+	if profileData == "" {
+		glog.V(100).Infof("'profileData' argument cannot be empty")
+
+		builder.errorMsg = "'profileData' argument cannot be empty"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.Definition.Spec.Profile = append(builder.Definition.Spec.Profile, tunedv1.TunedProfile{
+		Name: &profileName,
+		Data: &profileData,
+	})
+
+	return builder
+}
+
+// WithRecommend adds a recommend rule selecting the given profile with the given priority.
+// Lower priority value means higher priority.
+func (builder *TunedBuilder) WithRecommend(profileName string, priority uint64) *TunedBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding recommend rule for profile %s with priority %d to Tuned %s in namespace %s",
+		profileName, priority, builder.Definition.Name, builder.Definition.Namespace)
+
+	if profileName == "" {
+		glog.V(100).Infof("'profileName' argument cannot be empty")
+
+		builder.errorMsg = "'profileName' argument cannot be empty"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	builder.Definition.Spec.Recommend = append(builder.Definition.Spec.Recommend, tunedv1.TunedRecommend{
+		Profile:  &profileName,
+		Priority: &priority,
+	})
+
+	return builder
+}
+
+// WithRecommendMatch adds a match rule to the recommend rule of the given profile.
+func (builder *TunedBuilder) WithRecommendMatch(profileName string, match tunedv1.TunedMatch) *TunedBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding match rule %v to recommend rule of profile %s in Tuned %s",
+		match, profileName, builder.Definition.Name)
+
+	if match.Label == nil || *match.Label == "" {
+		glog.V(100).Infof("The match rule label cannot be empty")
+
+		builder.errorMsg = "'match' argument label cannot be empty"
+	}
+
+	recommend := builder.getRecommend(profileName)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	recommend.Match = append(recommend.Match, match)
+
+	return builder
+}
+
+// WithNodeLabelMatch adds a node label match rule to the recommend rule of the given profile.
+// An empty value matches any node having the label.
+func (builder *TunedBuilder) WithNodeLabelMatch(profileName, label, value string) *TunedBuilder {
+	matchType := "node"
+	match := tunedv1.TunedMatch{Label: &label, Type: &matchType}
+
+	if value != "" {
+		match.Value = &value
+	}
+
+	return builder.WithRecommendMatch(profileName, match)
+}
+
+// WithMachineConfigLabels defines the machineConfigLabels of the recommend rule of the given profile.
+// Nodes of the MachineConfigPools matching these labels receive the profile's kernel arguments.
+func (builder *TunedBuilder) WithMachineConfigLabels(profileName string, labels map[string]string) *TunedBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding machineConfigLabels %v to recommend rule of profile %s in Tuned %s",
+		labels, profileName, builder.Definition.Name)
+
+	if len(labels) == 0 {
+		glog.V(100).Infof("'labels' argument cannot be empty")
+
+		builder.errorMsg = "'labels' argument cannot be empty"
+	}
+
+	recommend := builder.getRecommend(profileName)
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	recommend.MachineConfigLabels = labels
+
+	return builder
+}
+
+// WithOptions creates Tuned with generic mutation options.
+func (builder *TunedBuilder) WithOptions(options ...TunedAdditionalOptions) *TunedBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting Tuned additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// Create makes a Tuned in the cluster and stores the created object in struct.
+func (builder *TunedBuilder) Create() (*TunedBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating Tuned %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	if !builder.Exists() {
+		err = builder.apiClient.Create(context.TODO(), builder.Definition)
+
+		if err != nil {
+			return nil, err
+		}
+
+		builder.Object, err = builder.Get()
+	}
+
+	return builder, err
+}
+
+// Exists checks whether the given Tuned exists.
+func (builder *TunedBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if Tuned %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
+}
+
+// Get fetches the defined Tuned from the cluster.
+func (builder *TunedBuilder) Get() (*tunedv1.Tuned, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting Tuned %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	tuned := &tunedv1.Tuned{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, tuned)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return tuned, err
+}
+
+// Update renovates the existing Tuned object with the Tuned definition in builder.
+func (builder *TunedBuilder) Update(force bool) (*TunedBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating Tuned %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update Tuned, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the Tuned object %s in namespace %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			builder, err := builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the Tuned object %s in namespace %s due to error in delete function",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return builder, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes the Tuned.
+func (builder *TunedBuilder) Delete() (*TunedBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Deleting Tuned %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("tuned cannot be deleted because it does not exist")
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+
+	if err != nil {
+		return builder, err
+	}
+
+	builder.Object = nil
+
+	return builder, err
+}
+
+// getRecommend returns the recommend rule of the given profile and sets errorMsg if there is none.
+func (builder *TunedBuilder) getRecommend(profileName string) *tunedv1.TunedRecommend {
+	for index := range builder.Definition.Spec.Recommend {
+		recommend := &builder.Definition.Spec.Recommend[index]
+
+		if recommend.Profile != nil && *recommend.Profile == profileName {
+			return recommend
+		}
+	}
+
+	glog.V(100).Infof("Tuned %s has no recommend rule for profile %s", builder.Definition.Name, profileName)
+
+	builder.errorMsg = fmt.Sprintf("no recommend rule defined for profile %s, use WithRecommend first", profileName)
+
+	return nil
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *TunedBuilder) validate() (bool, error) {
+	resourceCRD := "Tuned"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
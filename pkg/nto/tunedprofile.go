@@ -0,0 +1,250 @@
+package nto //nolint:misspell
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	tunedv1 "github.com/openshift/cluster-node-tuning-operator/pkg/apis/tuned/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// TunedProfileBuilder provides struct for the per node tuned Profile object which reports
+// the TuneD profile selected for and applied on a node.
+type TunedProfileBuilder struct {
+	// Dynamically discovered Profile object.
+	Object *tunedv1.Profile
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+	// nodeName defines for what node the Profile resource should be queried.
+	nodeName string
+	// nsName defines the node tuning operator namespace.
+	nsName string
+	// errorMsg used in discovery function before sending api request to cluster.
+	errorMsg string
+}
+
+// PullTunedProfile pulls the tuned Profile of the given node from cluster.
+func PullTunedProfile(apiClient *clients.Settings, nodeName, nsname string) (*TunedProfileBuilder, error) {
+	glog.V(100).Infof("Pulling existing tuned Profile of node %s in namespace %s", nodeName, nsname)
+
+	builder := &TunedProfileBuilder{
+		apiClient: apiClient,
+		nodeName:  nodeName,
+		nsName:    nsname,
+	}
+
+	if err := apiClient.AttachScheme(tunedv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add tuned profile scheme to client schemes")
+
+		return nil, err
+	}
+
+	if nodeName == "" {
+		glog.V(100).Infof("The nodeName of the tuned Profile is empty")
+
+		builder.errorMsg = "tuned Profile 'nodeName' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the tuned Profile is empty")
+
+		builder.errorMsg = "tuned Profile 'nsname' cannot be empty"
+	}
+
+	if err := builder.Discover(); err != nil {
+		return nil, fmt.Errorf("failed to pull tuned Profile of node %s: %w", nodeName, err)
+	}
+
+	return builder, nil
+}
+
+// Discover method gets the tuned Profile object and stores it in the TunedProfileBuilder struct.
+func (builder *TunedProfileBuilder) Discover() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Getting tuned Profile of node %s in namespace %s", builder.nodeName, builder.nsName)
+
+	profile := &tunedv1.Profile{}
+
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.nodeName,
+		Namespace: builder.nsName,
+	}, profile)
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = profile
+
+	return nil
+}
+
+// GetAppliedProfile returns the name of the TuneD profile applied on the node.
+// An error is returned if the selected profile has not been applied successfully yet.
+func (builder *TunedProfileBuilder) GetAppliedProfile() (string, error) {
+	if err := builder.Discover(); err != nil {
+		return "", err
+	}
+
+	glog.V(100).Infof("Getting applied TuneD profile of node %s", builder.nodeName)
+
+	if !builder.isConditionTrue(tunedv1.TunedProfileApplied) {
+		return "", fmt.Errorf("TuneD profile %s is not applied on node %s: %s",
+			builder.Object.Spec.Config.TunedProfile, builder.nodeName, builder.conditionMessage())
+	}
+
+	return builder.Object.Status.TunedProfile, nil
+}
+
+// IsDegraded returns true if the TuneD daemon reports the profile of the node as degraded.
+func (builder *TunedProfileBuilder) IsDegraded() (bool, error) {
+	if err := builder.Discover(); err != nil {
+		return false, err
+	}
+
+	return builder.isConditionTrue(tunedv1.TunedDegraded), nil
+}
+
+// WaitUntilApplied waits for the duration of the defined timeout or until the given
+// TuneD profile is applied on the node.
+func (builder *TunedProfileBuilder) WaitUntilApplied(profileName string, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until TuneD profile %s is applied on node %s",
+		profileName, builder.nodeName)
+
+	if profileName == "" {
+		glog.V(100).Infof("The profileName parameter is empty")
+
+		return fmt.Errorf("profileName can't be empty")
+	}
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		appliedProfile, err := builder.GetAppliedProfile()
+		if err != nil {
+			return false, nil
+		}
+
+		return appliedProfile == profileName, nil
+	})
+}
+
+// ListTunedProfiles returns the tuned Profiles of all nodes in the given namespace.
+func ListTunedProfiles(apiClient *clients.Settings, nsname string) ([]*TunedProfileBuilder, error) {
+	glog.V(100).Infof("Listing tuned Profiles in namespace %s", nsname)
+
+	if err := apiClient.AttachScheme(tunedv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add tuned profile scheme to client schemes")
+
+		return nil, err
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("tuned Profiles 'nsname' parameter can not be empty")
+
+		return nil, fmt.Errorf("failed to list tuned Profiles, 'nsname' parameter is empty")
+	}
+
+	var profiles tunedv1.ProfileList
+	err := apiClient.List(context.TODO(), &profiles, goclient.InNamespace(nsname))
+
+	if err != nil {
+		glog.V(100).Infof("Failed to list tuned Profiles due to %s", err.Error())
+
+		return nil, err
+	}
+
+	var profileObjects []*TunedProfileBuilder
+
+	for _, profile := range profiles.Items {
+		copiedProfile := profile
+		profileBuilder := &TunedProfileBuilder{
+			apiClient: apiClient,
+			Object:    &copiedProfile,
+			nodeName:  copiedProfile.Name,
+			nsName:    nsname,
+		}
+
+		profileObjects = append(profileObjects, profileBuilder)
+	}
+
+	return profileObjects, nil
+}
+
+// GetAppliedTunedProfiles returns a map of node name to the TuneD profile applied on it.
+// Nodes whose profile is not applied yet are reported with an empty profile name.
+func GetAppliedTunedProfiles(apiClient *clients.Settings, nsname string) (map[string]string, error) {
+	glog.V(100).Infof("Collecting applied TuneD profiles in namespace %s", nsname)
+
+	profiles, err := ListTunedProfiles(apiClient, nsname)
+	if err != nil {
+		return nil, err
+	}
+
+	appliedProfiles := make(map[string]string)
+
+	for _, profile := range profiles {
+		appliedProfiles[profile.nodeName] = ""
+
+		if profile.isConditionTrue(tunedv1.TunedProfileApplied) {
+			appliedProfiles[profile.nodeName] = profile.Object.Status.TunedProfile
+		}
+	}
+
+	return appliedProfiles, nil
+}
+
+func (builder *TunedProfileBuilder) isConditionTrue(conditionType tunedv1.ProfileConditionType) bool {
+	for _, condition := range builder.Object.Status.Conditions {
+		if condition.Type == conditionType {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
+
+func (builder *TunedProfileBuilder) conditionMessage() string {
+	for _, condition := range builder.Object.Status.Conditions {
+		if condition.Type == tunedv1.TunedProfileApplied {
+			return condition.Message
+		}
+	}
+
+	return "no Applied condition reported"
+}
+
+// validate will check that the builder is properly initialized before accessing any member fields.
+func (builder *TunedProfileBuilder) validate() (bool, error) {
+	resourceCRD := "Profile"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
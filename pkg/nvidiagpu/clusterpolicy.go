@@ -44,6 +44,12 @@ func NewBuilderFromObjectString(apiClient *clients.Settings, almExample string)
 		Definition: clusterPolicy,
 	}
 
+	if err := apiClient.AttachScheme(nvidiagpuv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterPolicy scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add clusterPolicy scheme to client schemes: %v", err)
+	}
+
 	if err != nil {
 		glog.V(100).Infof(
 			"Error initializing ClusterPolicy from alm-examples: %s", err.Error())
@@ -97,17 +103,29 @@ func Pull(apiClient *clients.Settings, name string) (*Builder, error) {
 		},
 	}
 
+	if err := apiClient.AttachScheme(nvidiagpuv1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add clusterPolicy scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("ClusterPolicy name is empty")
 
 		builder.errorMsg = "ClusterPolicy 'name' cannot be empty"
 	}
 
-	if !builder.Exists() {
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
 		return nil, fmt.Errorf("ClusterPolicy object %s doesn't exist", name)
 	}
 
-	builder.Definition = builder.Object
+	builder.Object = object
+	builder.Definition = object
 
 	return &builder, nil
 }
@@ -128,7 +146,7 @@ func (builder *Builder) Exists() bool {
 		glog.V(100).Infof("Failed to collect ClusterPolicy object due to %s", err.Error())
 	}
 
-	return err == nil || !k8serrors.IsNotFound(err)
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
 }
 
 // Delete removes a ClusterPolicy.
@@ -0,0 +1,435 @@
+package pod
+
+import (
+	"encoding/json"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// ipv4PingOverhead is the IPv4 and ICMP header size added to the ping payload.
+	ipv4PingOverhead = 28
+	// ipv6PingOverhead is the IPv6 and ICMPv6 header size added to the ping payload.
+	ipv6PingOverhead = 48
+	// defaultIperfPort is the default port used by iperf3 server.
+	defaultIperfPort = 5201
+	// iperfServerStartTimeout is how long the iperf3 client is retried while the daemonized server is not
+	// listening yet.
+	iperfServerStartTimeout = 10 * time.Second
+)
+
+var (
+	pingPacketsRegex = regexp.MustCompile(
+		`(\d+) packets transmitted, (\d+) (?:packets )?received,.*?([\d.]+)% packet loss`)
+	pingRTTRegex = regexp.MustCompile(
+		`(?:rtt|round-trip) min/avg/max(?:/mdev)? = ([\d.]+)/([\d.]+)/([\d.]+)(?:/([\d.]+))? ms`)
+)
+
+// PingOptions provides struct for the optional ping parameters. Zero values use the ping defaults.
+type PingOptions struct {
+	// Count is the number of echo requests to send. Default is 3.
+	Count int
+	// Interface is the source interface or address, e.g. net1.
+	Interface string
+	// PacketSize is the ICMP payload size in bytes.
+	PacketSize int
+	// Interval between echo requests.
+	Interval time.Duration
+	// Deadline after which ping exits regardless of the number of sent requests.
+	Deadline time.Duration
+	// DontFragment prohibits fragmentation, used for MTU probing.
+	DontFragment bool
+}
+
+// PingResult provides struct for the parsed ping statistics.
+type PingResult struct {
+	Transmitted int
+	Received    int
+	PacketLoss  float64
+	RTTMin      time.Duration
+	RTTAvg      time.Duration
+	RTTMax      time.Duration
+	RTTMdev     time.Duration
+}
+
+// ThroughputOptions provides struct for the optional iperf3 parameters. Zero values use the iperf3 defaults.
+type ThroughputOptions struct {
+	// Duration of the test. Default is 10 seconds.
+	Duration time.Duration
+	// Port used by the server. Default is 5201.
+	Port int
+	// UDP runs the test over UDP instead of TCP.
+	UDP bool
+	// Bandwidth is the target bandwidth, e.g. 1G. Required to exceed 1Mbit/sec with UDP.
+	Bandwidth string
+	// Parallel is the number of parallel client streams.
+	Parallel int
+	// Reverse makes the server send and the client receive.
+	Reverse bool
+}
+
+// ThroughputResult provides struct for the parsed iperf3 results.
+type ThroughputResult struct {
+	SentBitsPerSecond     float64
+	ReceivedBitsPerSecond float64
+	// Retransmits is reported for TCP tests only.
+	Retransmits int
+	// LostPercent and JitterMs are reported for UDP tests only.
+	LostPercent float64
+	JitterMs    float64
+}
+
+// iperfOutput represents the subset of the iperf3 json output used to build ThroughputResult.
+type iperfOutput struct {
+	Error string `json:"error"`
+	End   struct {
+		SumSent struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+			Retransmits   int     `json:"retransmits"`
+		} `json:"sum_sent"`
+		SumReceived struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+		} `json:"sum_received"`
+		Sum struct {
+			BitsPerSecond float64 `json:"bits_per_second"`
+			JitterMs      float64 `json:"jitter_ms"`
+			LostPercent   float64 `json:"lost_percent"`
+		} `json:"sum"`
+	} `json:"end"`
+}
+
+// Ping runs ping from the pod to the destination and returns the parsed statistics. Packet loss is reported
+// in the result and does not cause an error as long as ping statistics are printed.
+func (builder *Builder) Ping(destination string, options PingOptions) (*PingResult, error) {
+	if err := builder.validateExec(); err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Pinging %s from pod %s in namespace %s with options %+v",
+		destination, builder.Definition.Name, builder.Definition.Namespace, options)
+
+	if destination == "" {
+		return nil, fmt.Errorf("ping destination can not be empty")
+	}
+
+	output, err := builder.ExecCommand(buildPingCommand(destination, options))
+
+	result, parseErr := ParsePingOutput(output.String())
+	if parseErr != nil {
+		if err != nil {
+			return nil, fmt.Errorf("failed to ping %s from pod %s: %w, output: %s",
+				destination, builder.Definition.Name, err, output.String())
+		}
+
+		return nil, parseErr
+	}
+
+	return result, nil
+}
+
+// ParsePingOutput parses the statistics printed by iputils or busybox ping.
+func ParsePingOutput(output string) (*PingResult, error) {
+	packetsMatch := pingPacketsRegex.FindStringSubmatch(output)
+	if packetsMatch == nil {
+		return nil, fmt.Errorf("failed to find packet statistics in ping output: %s", output)
+	}
+
+	result := &PingResult{}
+	result.Transmitted, _ = strconv.Atoi(packetsMatch[1])
+	result.Received, _ = strconv.Atoi(packetsMatch[2])
+	result.PacketLoss, _ = strconv.ParseFloat(packetsMatch[3], 64)
+
+	// RTT statistics are not printed when no reply is received.
+	if rttMatch := pingRTTRegex.FindStringSubmatch(output); rttMatch != nil {
+		result.RTTMin = parseMilliseconds(rttMatch[1])
+		result.RTTAvg = parseMilliseconds(rttMatch[2])
+		result.RTTMax = parseMilliseconds(rttMatch[3])
+		result.RTTMdev = parseMilliseconds(rttMatch[4])
+	}
+
+	return result, nil
+}
+
+// ProbePathMTU finds the largest MTU between minMTU and maxMTU for which unfragmented packets sent from
+// the pod reach the destination. The optional interfaceName selects the source interface.
+func (builder *Builder) ProbePathMTU(destination, interfaceName string, minMTU, maxMTU int) (int, error) {
+	if err := builder.validateExec(); err != nil {
+		return 0, err
+	}
+
+	glog.V(100).Infof("Probing path MTU to %s from pod %s between %d and %d",
+		destination, builder.Definition.Name, minMTU, maxMTU)
+
+	overhead := ipv4PingOverhead
+
+	if destinationIP := net.ParseIP(destination); destinationIP != nil && destinationIP.To4() == nil {
+		overhead = ipv6PingOverhead
+	}
+
+	if minMTU <= overhead || minMTU > maxMTU {
+		return 0, fmt.Errorf("invalid MTU range %d-%d", minMTU, maxMTU)
+	}
+
+	probe := func(mtu int) (bool, error) {
+		result, err := builder.Ping(destination, PingOptions{
+			Count: 1, Interface: interfaceName, PacketSize: mtu - overhead, Deadline: 2 * time.Second, DontFragment: true})
+		if err != nil {
+			return false, err
+		}
+
+		return result.Received > 0, nil
+	}
+
+	reachable, err := probe(minMTU)
+	if err != nil {
+		return 0, err
+	}
+
+	if !reachable {
+		return 0, fmt.Errorf("destination %s is not reachable with minimum MTU %d", destination, minMTU)
+	}
+
+	lowest, highest := minMTU, maxMTU
+
+	for lowest < highest {
+		middle := (lowest + highest + 1) / 2
+
+		reachable, err := probe(middle)
+		if err != nil {
+			return 0, err
+		}
+
+		if reachable {
+			lowest = middle
+		} else {
+			highest = middle - 1
+		}
+	}
+
+	glog.V(100).Infof("Path MTU to %s from pod %s is %d", destination, builder.Definition.Name, lowest)
+
+	return lowest, nil
+}
+
+// MeasureThroughput starts a one-off iperf3 server in the server pod and runs the iperf3 client in the client
+// pod against serverIP. Both pods must provide the iperf3 binary. The client is retried while the server is
+// not listening yet, and the server is stopped when the measurement fails.
+func MeasureThroughput(client, server *Builder, serverIP string, options ThroughputOptions) (*ThroughputResult, error) {
+	if err := client.validateExec(); err != nil {
+		return nil, err
+	}
+
+	if err := server.validateExec(); err != nil {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Measuring throughput from pod %s to pod %s with address %s and options %+v",
+		client.Definition.Name, server.Definition.Name, serverIP, options)
+
+	if net.ParseIP(serverIP) == nil {
+		return nil, fmt.Errorf("invalid server address %q", serverIP)
+	}
+
+	port := options.Port
+	if port == 0 {
+		port = defaultIperfPort
+	}
+
+	output, err := server.ExecCommand(
+		[]string{"iperf3", "-s", "-1", "-D", "-p", strconv.Itoa(port), "-I", iperfPidFile(port)})
+	if err != nil {
+		return nil, fmt.Errorf("failed to start iperf3 server in pod %s: %w, output: %s",
+			server.Definition.Name, err, output.String())
+	}
+
+	result, err := runIperfClient(client, serverIP, port, options)
+	if err != nil {
+		server.stopIperfServer(port)
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// runIperfClient runs the iperf3 client until it connects to the server, which only listens some time after
+// iperf3 -D returns.
+func runIperfClient(client *Builder, serverIP string, port int, options ThroughputOptions) (*ThroughputResult, error) {
+	var (
+		result    *ThroughputResult
+		clientErr error
+	)
+
+	err := wait.PollImmediate(time.Second, iperfServerStartTimeout, func() (bool, error) {
+		// The client output is parsed even on error as iperf3 reports failures in its json output.
+		output, err := client.ExecCommand(buildIperfClientCommand(serverIP, port, options))
+
+		result, clientErr = parseIperfOutput(output.String())
+		if clientErr == nil {
+			return true, nil
+		}
+
+		if err != nil {
+			clientErr = fmt.Errorf("failed to run iperf3 client in pod %s: %w, output: %s",
+				client.Definition.Name, err, output.String())
+		}
+
+		if strings.Contains(output.String(), "Connection refused") {
+			glog.V(100).Infof("iperf3 server %s:%d is not listening yet", serverIP, port)
+
+			return false, nil
+		}
+
+		return false, clientErr
+	})
+
+	if err != nil {
+		if clientErr != nil {
+			return nil, clientErr
+		}
+
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// stopIperfServer kills the one-off iperf3 server so that it does not keep the port busy for the next measurement.
+func (builder *Builder) stopIperfServer(port int) {
+	glog.V(100).Infof("Stopping iperf3 server on port %d in pod %s", port, builder.Definition.Name)
+
+	output, err := builder.ExecCommand(
+		[]string{"sh", "-c", fmt.Sprintf("kill $(cat %[1]s) && rm -f %[1]s", iperfPidFile(port))})
+	if err != nil {
+		glog.V(100).Infof("Failed to stop iperf3 server in pod %s: %v, output: %s",
+			builder.Definition.Name, err, output.String())
+	}
+}
+
+// validateExec checks that the builder is valid and holds a pod created on or pulled from the cluster, which
+// is required to run commands in it.
+func (builder *Builder) validateExec() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	if builder.Object == nil {
+		glog.V(100).Infof("The pod %s in namespace %s has not been created or pulled",
+			builder.Definition.Name, builder.Definition.Namespace)
+
+		return fmt.Errorf("cannot run commands in pod %s in namespace %s: pod has not been created or pulled",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	return nil
+}
+
+func iperfPidFile(port int) string {
+	return fmt.Sprintf("/tmp/iperf3-%d.pid", port)
+}
+
+func buildPingCommand(destination string, options PingOptions) []string {
+	count := options.Count
+	if count == 0 {
+		count = 3
+	}
+
+	command := []string{"ping", "-c", strconv.Itoa(count)}
+
+	if options.Interface != "" {
+		command = append(command, "-I", options.Interface)
+	}
+
+	if options.PacketSize > 0 {
+		command = append(command, "-s", strconv.Itoa(options.PacketSize))
+	}
+
+	if options.Interval > 0 {
+		command = append(command, "-i", strconv.FormatFloat(options.Interval.Seconds(), 'f', -1, 64))
+	}
+
+	if options.Deadline > 0 {
+		command = append(command, "-w", strconv.Itoa(int(options.Deadline.Seconds())))
+	}
+
+	if options.DontFragment {
+		command = append(command, "-M", "do")
+	}
+
+	return append(command, destination)
+}
+
+func buildIperfClientCommand(serverIP string, port int, options ThroughputOptions) []string {
+	command := []string{"iperf3", "-c", serverIP, "-p", strconv.Itoa(port), "-J"}
+
+	if options.Duration > 0 {
+		command = append(command, "-t", strconv.Itoa(int(options.Duration.Seconds())))
+	}
+
+	if options.UDP {
+		command = append(command, "-u")
+	}
+
+	if options.Bandwidth != "" {
+		command = append(command, "-b", options.Bandwidth)
+	}
+
+	if options.Parallel > 0 {
+		command = append(command, "-P", strconv.Itoa(options.Parallel))
+	}
+
+	if options.Reverse {
+		command = append(command, "-R")
+	}
+
+	return command
+}
+
+func parseIperfOutput(output string) (*ThroughputResult, error) {
+	// Drop anything printed before the json document, e.g. warnings.
+	if start := strings.Index(output, "{"); start > 0 {
+		output = output[start:]
+	}
+
+	parsedOutput := &iperfOutput{}
+
+	if err := json.Unmarshal([]byte(output), parsedOutput); err != nil {
+		return nil, fmt.Errorf("failed to parse iperf3 output: %w, output: %s", err, output)
+	}
+
+	if parsedOutput.Error != "" {
+		return nil, fmt.Errorf("iperf3 failed: %s", parsedOutput.Error)
+	}
+
+	result := &ThroughputResult{
+		SentBitsPerSecond:     parsedOutput.End.SumSent.BitsPerSecond,
+		ReceivedBitsPerSecond: parsedOutput.End.SumReceived.BitsPerSecond,
+		Retransmits:           parsedOutput.End.SumSent.Retransmits,
+		LostPercent:           parsedOutput.End.Sum.LostPercent,
+		JitterMs:              parsedOutput.End.Sum.JitterMs,
+	}
+
+	// UDP tests report a single summary instead of sent and received summaries.
+	if result.SentBitsPerSecond == 0 && result.ReceivedBitsPerSecond == 0 {
+		result.SentBitsPerSecond = parsedOutput.End.Sum.BitsPerSecond
+		result.ReceivedBitsPerSecond = parsedOutput.End.Sum.BitsPerSecond * (100 - result.LostPercent) / 100
+	}
+
+	return result, nil
+}
+
+func parseMilliseconds(milliseconds string) time.Duration {
+	value, err := strconv.ParseFloat(milliseconds, 64)
+	if err != nil {
+		return 0
+	}
+
+	return time.Duration(value * float64(time.Millisecond))
+}
@@ -0,0 +1,233 @@
+package pod
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	nadV1 "github.com/k8snetworkplumbingwg/network-attachment-definition-client/pkg/apis/k8s.cni.cncf.io/v1"
+	multus "gopkg.in/k8snetworkplumbingwg/multus-cni.v4/pkg/types"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// networksAnnotation is the annotation used to request Multus secondary networks.
+const networksAnnotation = "k8s.v1.cni.cncf.io/networks"
+
+// GetNetworkStatus returns the entries of the network-status annotation reported by Multus for the pod,
+// including the cluster default network.
+func (builder *Builder) GetNetworkStatus() ([]nadV1.NetworkStatus, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Getting network-status of pod %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	pod, err := builder.apiClient.Pods(builder.Definition.Namespace).Get(
+		context.Background(), builder.Definition.Name, metaV1.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	builder.Object = pod
+
+	statusAnnotation, found := pod.Annotations[nadV1.NetworkStatusAnnot]
+	if !found {
+		return nil, fmt.Errorf("pod %s in namespace %s does not have %s annotation",
+			pod.Name, pod.Namespace, nadV1.NetworkStatusAnnot)
+	}
+
+	var networkStatus []nadV1.NetworkStatus
+
+	if err := json.Unmarshal([]byte(statusAnnotation), &networkStatus); err != nil {
+		return nil, fmt.Errorf("failed to parse %s annotation of pod %s: %w", nadV1.NetworkStatusAnnot, pod.Name, err)
+	}
+
+	return networkStatus, nil
+}
+
+// GetSecondaryNetworkStatus returns the network-status entries of the pod secondary networks.
+func (builder *Builder) GetSecondaryNetworkStatus() ([]nadV1.NetworkStatus, error) {
+	networkStatus, err := builder.GetNetworkStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	var secondaryNetworkStatus []nadV1.NetworkStatus
+
+	for _, status := range networkStatus {
+		if !status.Default {
+			secondaryNetworkStatus = append(secondaryNetworkStatus, status)
+		}
+	}
+
+	return secondaryNetworkStatus, nil
+}
+
+// GetInterfaceNetworkStatus returns the network-status entry of the given pod interface, e.g. net1.
+func (builder *Builder) GetInterfaceNetworkStatus(interfaceName string) (*nadV1.NetworkStatus, error) {
+	networkStatus, err := builder.GetNetworkStatus()
+	if err != nil {
+		return nil, err
+	}
+
+	for index := range networkStatus {
+		if networkStatus[index].Interface == interfaceName {
+			return &networkStatus[index], nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %s is not reported in network-status of pod %s",
+		interfaceName, builder.Definition.Name)
+}
+
+// GetInterfacePCIAddress returns the PCI address of the device backing the given pod interface,
+// e.g. the SR-IOV VF.
+func (builder *Builder) GetInterfacePCIAddress(interfaceName string) (string, error) {
+	status, err := builder.GetInterfaceNetworkStatus(interfaceName)
+	if err != nil {
+		return "", err
+	}
+
+	if status.DeviceInfo == nil || status.DeviceInfo.Pci == nil || status.DeviceInfo.Pci.PciAddress == "" {
+		return "", fmt.Errorf("interface %s of pod %s does not report PCI device-info",
+			interfaceName, builder.Definition.Name)
+	}
+
+	return status.DeviceInfo.Pci.PciAddress, nil
+}
+
+// WaitUntilSecondaryNetworksReady waits for the duration of the defined timeout or until every secondary network
+// requested in the pod networks annotation is reported in network-status with the requested interface name,
+// IP addresses and MAC address.
+func (builder *Builder) WaitUntilSecondaryNetworksReady(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until secondary networks of pod %s in namespace %s are ready",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	requestedNetworks, err := parseNetworksAnnotation(
+		builder.Definition.Annotations[networksAnnotation], builder.Definition.Namespace)
+	if err != nil {
+		return err
+	}
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		networkStatus, err := builder.GetNetworkStatus()
+		if err != nil {
+			glog.V(100).Infof("Failed to get network-status of pod %s: %v", builder.Definition.Name, err)
+
+			return false, nil
+		}
+
+		for _, requestedNetwork := range requestedNetworks {
+			if !isNetworkReported(requestedNetwork, networkStatus) {
+				glog.V(100).Infof("Network %s/%s is not reported in network-status of pod %s yet",
+					requestedNetwork.Namespace, requestedNetwork.Name, builder.Definition.Name)
+
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// parseNetworksAnnotation parses the networks annotation in either json or comma separated
+// <namespace>/<name>@<interface> format.
+func parseNetworksAnnotation(annotation, podNamespace string) ([]*multus.NetworkSelectionElement, error) {
+	var requestedNetworks []*multus.NetworkSelectionElement
+
+	annotation = strings.TrimSpace(annotation)
+
+	if strings.HasPrefix(annotation, "[") {
+		if err := json.Unmarshal([]byte(annotation), &requestedNetworks); err != nil {
+			return nil, fmt.Errorf("failed to parse %s annotation: %w", networksAnnotation, err)
+		}
+	} else if annotation != "" {
+		for _, network := range strings.Split(annotation, ",") {
+			requestedNetwork := &multus.NetworkSelectionElement{}
+			network = strings.TrimSpace(network)
+
+			if name, interfaceName, found := strings.Cut(network, "@"); found {
+				network = name
+				requestedNetwork.InterfaceRequest = interfaceName
+			}
+
+			if namespace, name, found := strings.Cut(network, "/"); found {
+				requestedNetwork.Namespace = namespace
+				network = name
+			}
+
+			requestedNetwork.Name = network
+			requestedNetworks = append(requestedNetworks, requestedNetwork)
+		}
+	}
+
+	for _, requestedNetwork := range requestedNetworks {
+		if requestedNetwork.Namespace == "" {
+			requestedNetwork.Namespace = podNamespace
+		}
+	}
+
+	return requestedNetworks, nil
+}
+
+// isNetworkReported checks whether the network-status contains an entry matching the requested network.
+func isNetworkReported(requestedNetwork *multus.NetworkSelectionElement, networkStatus []nadV1.NetworkStatus) bool {
+	networkName := fmt.Sprintf("%s/%s", requestedNetwork.Namespace, requestedNetwork.Name)
+
+	for _, status := range networkStatus {
+		if status.Name != networkName {
+			continue
+		}
+
+		if requestedNetwork.InterfaceRequest != "" && status.Interface != requestedNetwork.InterfaceRequest {
+			continue
+		}
+
+		if requestedNetwork.MacRequest != "" && !strings.EqualFold(status.Mac, requestedNetwork.MacRequest) {
+			continue
+		}
+
+		if hasIPAddresses(status.IPs, requestedNetwork.IPRequest) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasIPAddresses checks whether all requested addresses, optionally in CIDR notation, are present in the list.
+func hasIPAddresses(ipAddresses, requestedAddresses []string) bool {
+	for _, requestedAddress := range requestedAddresses {
+		requestedIP := net.ParseIP(requestedAddress)
+
+		if ipAddr, _, err := net.ParseCIDR(requestedAddress); err == nil {
+			requestedIP = ipAddr
+		}
+
+		found := false
+
+		for _, ipAddress := range ipAddresses {
+			if requestedIP != nil && requestedIP.Equal(net.ParseIP(ipAddress)) {
+				found = true
+
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
@@ -3,6 +3,9 @@ package service
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
+	"time"
 
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
 
@@ -13,6 +16,17 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/wait"
+
+	discoveryV1 "k8s.io/api/discovery/v1"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// MetalLBAddressPoolAnnotation requests an address from the given MetalLB IPAddressPool.
+	MetalLBAddressPoolAnnotation = "metallb.universe.tf/address-pool"
+	// MetalLBLoadBalancerIPsAnnotation requests specific load balancer IPs from MetalLB.
+	MetalLBLoadBalancerIPsAnnotation = "metallb.universe.tf/loadBalancerIPs"
 )
 
 // Builder provides struct for service object containing connection to the cluster and the service definitions.
@@ -32,7 +46,7 @@ type AdditionalOptions func(builder *Builder) (*Builder, error)
 
 // NewBuilder creates a new instance of Builder
 // Default type of service is ClusterIP
-// Use WithNodePort() for setting the NodePort type.
+// Use WithNodePort() for setting the NodePort type and WithLoadBalancer() for the LoadBalancer type.
 func NewBuilder(
 	apiClient *clients.Settings,
 	name string,
@@ -90,6 +104,177 @@ func (builder *Builder) WithNodePort() *Builder {
 	return builder
 }
 
+// WithLoadBalancer redefines the service with LoadBalancer service type.
+func (builder *Builder) WithLoadBalancer() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s with LoadBalancer type",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.ClusterIP == v1.ClusterIPNone {
+		builder.errorMsg = "headless service cannot be of LoadBalancer type"
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = v1.ServiceTypeLoadBalancer
+
+	return builder
+}
+
+// WithAddressPool redefines the service with LoadBalancer type requesting its address from the given MetalLB pool.
+func (builder *Builder) WithAddressPool(poolName string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s with address pool %s",
+		builder.Definition.Name, builder.Definition.Namespace, poolName)
+
+	if poolName == "" {
+		builder.errorMsg = "address pool name can not be empty"
+
+		return builder
+	}
+
+	builder.setAnnotation(MetalLBAddressPoolAnnotation, poolName)
+
+	return builder.WithLoadBalancer()
+}
+
+// WithLoadBalancerIPs redefines the service with LoadBalancer type requesting the given IPs from MetalLB.
+// Up to one IPv4 and one IPv6 address can be requested for dual-stack services.
+func (builder *Builder) WithLoadBalancerIPs(ipAddresses ...string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s with load balancer IPs %v",
+		builder.Definition.Name, builder.Definition.Namespace, ipAddresses)
+
+	if len(ipAddresses) < 1 || len(ipAddresses) > 2 {
+		builder.errorMsg = "load balancer IPs must contain one or two addresses"
+
+		return builder
+	}
+
+	for _, ipAddress := range ipAddresses {
+		if net.ParseIP(ipAddress) == nil {
+			builder.errorMsg = fmt.Sprintf("invalid load balancer IP %s", ipAddress)
+
+			return builder
+		}
+	}
+
+	if len(ipAddresses) == 2 &&
+		(net.ParseIP(ipAddresses[0]).To4() == nil) == (net.ParseIP(ipAddresses[1]).To4() == nil) {
+		builder.errorMsg = "two load balancer IPs must be of different IP families"
+
+		return builder
+	}
+
+	builder.setAnnotation(MetalLBLoadBalancerIPsAnnotation, strings.Join(ipAddresses, ","))
+
+	return builder.WithLoadBalancer()
+}
+
+// WithHeadless redefines the service as headless ClusterIP service without a cluster IP.
+func (builder *Builder) WithHeadless() *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Defining service %s in namespace %s as headless",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if builder.Definition.Spec.Type != "" && builder.Definition.Spec.Type != v1.ServiceTypeClusterIP {
+		builder.errorMsg = fmt.Sprintf("service of %s type cannot be headless", builder.Definition.Spec.Type)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Type = v1.ServiceTypeClusterIP
+	builder.Definition.Spec.ClusterIP = v1.ClusterIPNone
+
+	return builder
+}
+
+// WithPortName sets the name of the service port with the given port number and protocol. It is used to name
+// the port defined by DefineServicePort before adding further ports with WithAdditionalPort.
+func (builder *Builder) WithPortName(port int32, protocol v1.Protocol, name string) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting name %s of port %s %d of service %s in namespace %s",
+		name, protocol, port, builder.Definition.Name, builder.Definition.Namespace)
+
+	if name == "" {
+		builder.errorMsg = "service port name cannot be empty"
+
+		return builder
+	}
+
+	portIndex := -1
+
+	for index, servicePort := range builder.Definition.Spec.Ports {
+		if servicePort.Port == port && servicePort.Protocol == protocol {
+			portIndex = index
+
+			continue
+		}
+
+		if servicePort.Name == name {
+			builder.errorMsg = fmt.Sprintf("service already has a port named %s", name)
+
+			return builder
+		}
+	}
+
+	if portIndex == -1 {
+		builder.errorMsg = fmt.Sprintf("service has no port %s %d", protocol, port)
+
+		return builder
+	}
+
+	builder.Definition.Spec.Ports[portIndex].Name = name
+
+	return builder
+}
+
+// WithAdditionalPort appends the given port to the service. Services with multiple ports
+// require every port to have a unique name, existing ports can be named with WithPortName.
+func (builder *Builder) WithAdditionalPort(servicePort v1.ServicePort) *Builder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Adding port %s %d to service %s in namespace %s",
+		servicePort.Name, servicePort.Port, builder.Definition.Name, builder.Definition.Namespace)
+
+	for _, port := range append([]v1.ServicePort{servicePort}, builder.Definition.Spec.Ports...) {
+		if port.Name == "" {
+			builder.errorMsg = "all ports of a multi-port service must be named, use WithPortName for existing ports"
+
+			return builder
+		}
+	}
+
+	for _, port := range builder.Definition.Spec.Ports {
+		if port.Name == servicePort.Name {
+			builder.errorMsg = fmt.Sprintf("service already has a port named %s", servicePort.Name)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.Ports = append(builder.Definition.Spec.Ports, servicePort)
+
+	return builder
+}
+
 // Pull loads an existing service into Builder struct.
 func Pull(apiClient *clients.Settings, name, nsname string) (*Builder, error) {
 	glog.V(100).Infof("Pulling existing service name: %s under namespace: %s", name, nsname)
@@ -138,6 +323,34 @@ func (builder *Builder) Create() (*Builder, error) {
 	return builder, err
 }
 
+// Update renovates the existing service object with the service definition in builder.
+func (builder *Builder) Update() (*Builder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the service %s in namespace %s", builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return builder, fmt.Errorf("service %s cannot be updated because it does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	// Cluster IPs are immutable and allocated by the cluster when not requested explicitly.
+	if builder.Definition.Spec.ClusterIP == "" {
+		builder.Definition.Spec.ClusterIP = builder.Object.Spec.ClusterIP
+		builder.Definition.Spec.ClusterIPs = builder.Object.Spec.ClusterIPs
+	}
+
+	var err error
+	builder.Object, err = builder.apiClient.Services(builder.Definition.Namespace).Update(
+		context.TODO(), builder.Definition, metaV1.UpdateOptions{})
+
+	return builder, err
+}
+
 // Exists checks whether the given service exists.
 func (builder *Builder) Exists() bool {
 	if valid, _ := builder.validate(); !valid {
@@ -290,6 +503,101 @@ func (builder *Builder) WithIPFamily(ipFamily []v1.IPFamily, ipStackPolicy v1.IP
 	return builder
 }
 
+// WaitUntilLoadBalancerIngress waits for the duration of the defined timeout or until
+// the load balancer assigned at least one ingress address to the service.
+func (builder *Builder) WaitUntilLoadBalancerIngress(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until service %s in namespace %s has load balancer ingress",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		if !builder.Exists() || builder.Object == nil {
+			return false, nil
+		}
+
+		return len(builder.Object.Status.LoadBalancer.Ingress) > 0, nil
+	})
+}
+
+// GetLoadBalancerIngressIPs returns the ingress IPs assigned to the service by the load balancer.
+func (builder *Builder) GetLoadBalancerIngressIPs() ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	if !builder.Exists() || builder.Object == nil {
+		return nil, fmt.Errorf("service %s does not exist in namespace %s",
+			builder.Definition.Name, builder.Definition.Namespace)
+	}
+
+	var ingressIPs []string
+
+	for _, ingress := range builder.Object.Status.LoadBalancer.Ingress {
+		if ingress.IP != "" {
+			ingressIPs = append(ingressIPs, ingress.IP)
+		}
+	}
+
+	return ingressIPs, nil
+}
+
+// WaitUntilEndpointsReady waits for the duration of the defined timeout or until
+// the EndpointSlices of the service report at least minReady ready endpoints.
+func (builder *Builder) WaitUntilEndpointsReady(minReady int, timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting until service %s in namespace %s has %d ready endpoints",
+		builder.Definition.Name, builder.Definition.Namespace, minReady)
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		readyAddresses, err := builder.GetReadyEndpointAddresses()
+		if err != nil {
+			glog.V(100).Infof("Failed to collect endpoints of service %s due to %v", builder.Definition.Name, err)
+
+			return false, nil
+		}
+
+		return len(readyAddresses) >= minReady, nil
+	})
+}
+
+// GetReadyEndpointAddresses returns the addresses of the ready endpoints from the EndpointSlices of the service.
+func (builder *Builder) GetReadyEndpointAddresses() ([]string, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	endpointSlices := &discoveryV1.EndpointSliceList{}
+
+	err := builder.apiClient.List(context.TODO(), endpointSlices,
+		goclient.InNamespace(builder.Definition.Namespace),
+		goclient.MatchingLabels{discoveryV1.LabelServiceName: builder.Definition.Name})
+
+	if err != nil {
+		return nil, err
+	}
+
+	var readyAddresses []string
+
+	for _, endpointSlice := range endpointSlices.Items {
+		for _, endpoint := range endpointSlice.Endpoints {
+			// A nil ready condition must be interpreted as ready.
+			if endpoint.Conditions.Ready != nil && !*endpoint.Conditions.Ready {
+				continue
+			}
+
+			readyAddresses = append(readyAddresses, endpoint.Addresses...)
+		}
+	}
+
+	return readyAddresses, nil
+}
+
 // DefineServicePort helper for creating a Service with a ServicePort.
 func DefineServicePort(port, targetPort int32, protocol v1.Protocol) (*v1.ServicePort, error) {
 	glog.V(100).Infof(
@@ -320,6 +628,14 @@ func GetServiceGVR() schema.GroupVersionResource {
 	}
 }
 
+func (builder *Builder) setAnnotation(key, value string) {
+	if builder.Definition.Annotations == nil {
+		builder.Definition.Annotations = make(map[string]string)
+	}
+
+	builder.Definition.Annotations[key] = value
+}
+
 // isValidPort checks if a port is valid.
 func isValidPort(port int32) bool {
 	if (port > 0) || (port < 65535) {
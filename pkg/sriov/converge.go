@@ -0,0 +1,368 @@
+package sriov
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang/glog"
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"golang.org/x/exp/slices"
+	coreV1 "k8s.io/api/core/v1"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// convergenceRetryInterval defines how often SriovNetworkNodeStates are polled while waiting for convergence.
+	convergenceRetryInterval = 3 * time.Second
+	// resourcePrefix is the prefix the sriov device plugin uses to advertise resources in node allocatable.
+	resourcePrefix = "openshift.io/"
+	// syncStatusSucceeded is the SriovNetworkNodeState syncStatus reported once the node configuration is applied.
+	syncStatusSucceeded = "Succeeded"
+	// defaultDeviceType is the deviceType the operator applies to SriovNetworkNodePolicies that do not set one.
+	defaultDeviceType = "netdevice"
+)
+
+// CreatePoliciesAndWaitUntilConverged creates the given SriovNetworkNodePolicies and waits until
+// every affected node has applied them. See WaitUntilPoliciesConverged for the convergence criteria.
+func CreatePoliciesAndWaitUntilConverged(
+	apiClient *clients.Settings, timeout time.Duration, policies ...*PolicyBuilder) error {
+	glog.V(100).Infof("Creating %d SriovNetworkNodePolicies and waiting until nodes converge", len(policies))
+
+	if err := validatePolicyBuilders(policies); err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if _, err := policy.Create(); err != nil {
+			return fmt.Errorf("failed to create SriovNetworkNodePolicy %s: %w", policy.Definition.Name, err)
+		}
+	}
+
+	return WaitUntilPoliciesConverged(apiClient, timeout, policies...)
+}
+
+// UpdatePoliciesAndWaitUntilConverged updates the given SriovNetworkNodePolicies and waits until
+// every affected node has applied them. See WaitUntilPoliciesConverged for the convergence criteria.
+func UpdatePoliciesAndWaitUntilConverged(
+	apiClient *clients.Settings, timeout time.Duration, policies ...*PolicyBuilder) error {
+	glog.V(100).Infof("Updating %d SriovNetworkNodePolicies and waiting until nodes converge", len(policies))
+
+	if err := validatePolicyBuilders(policies); err != nil {
+		return err
+	}
+
+	for _, policy := range policies {
+		if _, err := policy.Update(false); err != nil {
+			return fmt.Errorf("failed to update SriovNetworkNodePolicy %s: %w", policy.Definition.Name, err)
+		}
+	}
+
+	return WaitUntilPoliciesConverged(apiClient, timeout, policies...)
+}
+
+// DeletePoliciesAndWaitUntilConverged removes the given SriovNetworkNodePolicies and waits until no
+// SriovNetworkNodeState references them anymore and every node reports syncStatus Succeeded.
+func DeletePoliciesAndWaitUntilConverged(
+	apiClient *clients.Settings, timeout time.Duration, policies ...*PolicyBuilder) error {
+	glog.V(100).Infof("Deleting %d SriovNetworkNodePolicies and waiting until nodes converge", len(policies))
+
+	if err := validatePolicyBuilders(policies); err != nil {
+		return err
+	}
+
+	var policyNames []string
+
+	for _, policy := range policies {
+		if err := policy.Delete(); err != nil {
+			return fmt.Errorf("failed to delete SriovNetworkNodePolicy %s: %w", policy.Definition.Name, err)
+		}
+
+		policyNames = append(policyNames, policy.Definition.Name)
+	}
+
+	nsname := policies[0].Definition.Namespace
+
+	return wait.PollImmediate(convergenceRetryInterval, timeout, func() (bool, error) {
+		nodeStates, err := ListNetworkNodeState(apiClient, nsname, metaV1.ListOptions{})
+		if err != nil {
+			return false, nil
+		}
+
+		for _, nodeState := range nodeStates {
+			if nodeState.Objects.Status.SyncStatus != syncStatusSucceeded {
+				glog.V(100).Infof("SriovNetworkNodeState %s has syncStatus %s",
+					nodeState.Objects.Name, nodeState.Objects.Status.SyncStatus)
+
+				return false, nil
+			}
+
+			for _, specInterface := range nodeState.Objects.Spec.Interfaces {
+				for _, vfGroup := range specInterface.VfGroups {
+					if slices.Contains(policyNames, vfGroup.PolicyName) {
+						glog.V(100).Infof("SriovNetworkNodeState %s still references SriovNetworkNodePolicy %s",
+							nodeState.Objects.Name, vfGroup.PolicyName)
+
+						return false, nil
+					}
+				}
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// WaitUntilPoliciesConverged waits for the duration of the defined timeout or until every node affected by
+// the given SriovNetworkNodePolicies has applied them. Every node matching the policy nodeSelector must have a
+// SriovNetworkNodeState reporting its interfaces with syncStatus Succeeded. A node is affected by a policy when
+// it also exposes a PF matching the policy nicSelector. An affected node has converged when:
+//   - its SriovNetworkNodeState spec contains the VF group rendered from the current policy spec for every
+//     matching PF, which shows the operator has observed the current policy generation;
+//   - every matching PF exposes the numVfs requested in the spec;
+//   - the policy resource is advertised in the node allocatable.
+func WaitUntilPoliciesConverged(apiClient *clients.Settings, timeout time.Duration, policies ...*PolicyBuilder) error {
+	glog.V(100).Infof("Waiting for the defined period until %d SriovNetworkNodePolicies are applied", len(policies))
+
+	if err := validatePolicyBuilders(policies); err != nil {
+		return err
+	}
+
+	nsname := policies[0].Definition.Namespace
+
+	return wait.PollImmediate(convergenceRetryInterval, timeout, func() (bool, error) {
+		nodeStates, err := ListNetworkNodeState(apiClient, nsname, metaV1.ListOptions{})
+		if err != nil {
+			return false, nil
+		}
+
+		for _, policy := range policies {
+			converged, err := isPolicyConverged(apiClient, policy, nodeStates)
+			if err != nil || !converged {
+				return false, nil
+			}
+		}
+
+		return true, nil
+	})
+}
+
+// isPolicyConverged checks whether all nodes affected by the policy have applied it.
+func isPolicyConverged(
+	apiClient *clients.Settings, policy *PolicyBuilder, nodeStates []*NetworkNodeStateBuilder) (bool, error) {
+	nodes, err := apiClient.CoreV1Interface.Nodes().List(context.TODO(), metaV1.ListOptions{
+		LabelSelector: labels.Set(policy.Definition.Spec.NodeSelector).String(),
+	})
+
+	if err != nil {
+		return false, err
+	}
+
+	for index := range nodes.Items {
+		node := &nodes.Items[index]
+		nodeState := getNodeState(nodeStates, node.Name)
+
+		if nodeState == nil {
+			glog.V(100).Infof("Node %s selected by SriovNetworkNodePolicy %s has no SriovNetworkNodeState yet",
+				node.Name, policy.Definition.Name)
+
+			return false, nil
+		}
+
+		if !isNodeStateConverged(nodeState, policy.Definition, node) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// getNodeState returns the SriovNetworkNodeState of the node with the given name.
+func getNodeState(nodeStates []*NetworkNodeStateBuilder, nodeName string) *srIovV1.SriovNetworkNodeState {
+	for _, nodeState := range nodeStates {
+		if nodeState.Objects != nil && nodeState.Objects.Name == nodeName {
+			return nodeState.Objects
+		}
+	}
+
+	return nil
+}
+
+// isNodeStateConverged checks whether the policy has been applied to the node described by the node state.
+func isNodeStateConverged(
+	nodeState *srIovV1.SriovNetworkNodeState, policy *srIovV1.SriovNetworkNodePolicy, node *coreV1.Node) bool {
+	if len(nodeState.Status.Interfaces) == 0 {
+		glog.V(100).Infof("SriovNetworkNodeState %s does not report any interface yet", nodeState.Name)
+
+		return false
+	}
+
+	if nodeState.Status.SyncStatus != syncStatusSucceeded {
+		glog.V(100).Infof("SriovNetworkNodeState %s has syncStatus %s", nodeState.Name, nodeState.Status.SyncStatus)
+
+		return false
+	}
+
+	var matchingPFs srIovV1.InterfaceExts
+
+	for _, statusInterface := range nodeState.Status.Interfaces {
+		if nicSelectorMatches(policy.Spec.NicSelector, statusInterface) {
+			matchingPFs = append(matchingPFs, statusInterface)
+		}
+	}
+
+	// The policy selects no PF on this node, so there is nothing left to configure.
+	if len(matchingPFs) == 0 {
+		return true
+	}
+
+	for _, statusInterface := range matchingPFs {
+		specInterface := getSpecInterface(nodeState, statusInterface.PciAddress)
+
+		if specInterface == nil || !hasPolicyVfGroup(specInterface, policy) {
+			glog.V(100).Infof("SriovNetworkNodeState %s does not reflect SriovNetworkNodePolicy %s on PF %s yet",
+				nodeState.Name, policy.Name, statusInterface.Name)
+
+			return false
+		}
+
+		if statusInterface.NumVfs != specInterface.NumVfs {
+			glog.V(100).Infof("PF %s on node %s has %d VFs, expected %d",
+				statusInterface.Name, nodeState.Name, statusInterface.NumVfs, specInterface.NumVfs)
+
+			return false
+		}
+	}
+
+	allocatable, found := node.Status.Allocatable[coreV1.ResourceName(resourcePrefix+policy.Spec.ResourceName)]
+	if !found || allocatable.IsZero() {
+		glog.V(100).Infof("Resource %s is not advertised on node %s yet", policy.Spec.ResourceName, node.Name)
+
+		return false
+	}
+
+	return true
+}
+
+// getSpecInterface returns the SriovNetworkNodeState spec interface with the given PCI address.
+func getSpecInterface(nodeState *srIovV1.SriovNetworkNodeState, pciAddress string) *srIovV1.Interface {
+	for index := range nodeState.Spec.Interfaces {
+		if nodeState.Spec.Interfaces[index].PciAddress == pciAddress {
+			return &nodeState.Spec.Interfaces[index]
+		}
+	}
+
+	return nil
+}
+
+// hasPolicyVfGroup checks whether the spec interface contains the VF group rendered from the current policy spec.
+// NumVfs, DeviceType, Mtu and VfRange are compared as well, so that a VF group rendered from an earlier
+// version of the policy is not mistaken for the updated one.
+func hasPolicyVfGroup(specInterface *srIovV1.Interface, policy *srIovV1.SriovNetworkNodePolicy) bool {
+	if specInterface.NumVfs != policy.Spec.NumVfs {
+		return false
+	}
+
+	deviceType := policy.Spec.DeviceType
+	if deviceType == "" {
+		deviceType = defaultDeviceType
+	}
+
+	vfRange, err := getPolicyVfRange(policy, specInterface.Name)
+	if err != nil {
+		glog.V(100).Infof("Failed to get VF range of SriovNetworkNodePolicy %s: %v", policy.Name, err)
+
+		return false
+	}
+
+	for _, vfGroup := range specInterface.VfGroups {
+		if vfGroup.PolicyName == policy.Name &&
+			vfGroup.ResourceName == policy.Spec.ResourceName &&
+			vfGroup.DeviceType == deviceType &&
+			vfGroup.Mtu == policy.Spec.Mtu &&
+			vfGroup.VfRange == vfRange {
+			return true
+		}
+	}
+
+	return false
+}
+
+// getPolicyVfRange returns the VF range the operator renders for the policy on the given PF.
+func getPolicyVfRange(policy *srIovV1.SriovNetworkNodePolicy, pfName string) (string, error) {
+	rangeStart, rangeEnd := 0, policy.Spec.NumVfs-1
+
+	for _, selector := range policy.Spec.NicSelector.PfNames {
+		name, start, end, err := srIovV1.ParsePFName(selector)
+		if err != nil {
+			return "", err
+		}
+
+		if name != pfName {
+			continue
+		}
+
+		// ParsePFName returns negative indexes when the PF name carries no VF partition suffix.
+		if start >= 0 && end >= 0 {
+			rangeStart, rangeEnd = start, end
+		}
+
+		break
+	}
+
+	return fmt.Sprintf("%d-%d", rangeStart, rangeEnd), nil
+}
+
+// nicSelectorMatches checks whether the interface is selected by the nicSelector.
+func nicSelectorMatches(selector srIovV1.SriovNetworkNicSelector, nic srIovV1.InterfaceExt) bool {
+	if selector.Vendor != "" && selector.Vendor != nic.Vendor {
+		return false
+	}
+
+	if selector.DeviceID != "" && selector.DeviceID != nic.DeviceID {
+		return false
+	}
+
+	if len(selector.RootDevices) > 0 && !slices.Contains(selector.RootDevices, nic.PciAddress) {
+		return false
+	}
+
+	if selector.NetFilter != "" && selector.NetFilter != nic.NetFilter {
+		return false
+	}
+
+	if len(selector.PfNames) > 0 {
+		var pfNames []string
+
+		// PF names may carry a VF partition suffix, e.g. ens1f0#0-3.
+		for _, pfName := range selector.PfNames {
+			pfNames = append(pfNames, strings.Split(pfName, "#")[0])
+		}
+
+		if !slices.Contains(pfNames, nic.Name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validatePolicyBuilders(policies []*PolicyBuilder) error {
+	if len(policies) == 0 {
+		glog.V(100).Infof("The list of SriovNetworkNodePolicies is empty")
+
+		return fmt.Errorf("no SriovNetworkNodePolicies provided")
+	}
+
+	for _, policy := range policies {
+		if valid, err := policy.validate(); !valid {
+			return err
+		}
+	}
+
+	return nil
+}
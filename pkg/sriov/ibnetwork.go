@@ -0,0 +1,390 @@
+package sriov
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/golang/glog"
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	"golang.org/x/exp/slices"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	goclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IBNetworkBuilder provides struct for SriovIBNetwork object which contains connection to cluster and
+// SriovIBNetwork definition.
+type IBNetworkBuilder struct {
+	// SriovIBNetwork definition. Used to create SriovIBNetwork object.
+	Definition *srIovV1.SriovIBNetwork
+	// Created SriovIBNetwork object.
+	Object *srIovV1.SriovIBNetwork
+	// Used in functions that define or mutate SriovIBNetwork definitions. errorMsg is processed before
+	// SriovIBNetwork object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// IBNetworkAdditionalOptions additional options for SriovIBNetwork object.
+type IBNetworkAdditionalOptions func(builder *IBNetworkBuilder) (*IBNetworkBuilder, error)
+
+// NewIBNetworkBuilder creates new instance of IBNetworkBuilder.
+func NewIBNetworkBuilder(
+	apiClient *clients.Settings, name, nsname, targetNsname, resName string) *IBNetworkBuilder {
+	glog.V(100).Infof(
+		"Initializing new IBNetworkBuilder structure with the following params: %s, %s, %s, %s",
+		name, nsname, targetNsname, resName)
+
+	builder := IBNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &srIovV1.SriovIBNetwork{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+			Spec: srIovV1.SriovIBNetworkSpec{
+				ResourceName:     resName,
+				NetworkNamespace: targetNsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(srIovV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add SriovIBNetwork scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add SriovIBNetwork scheme to client schemes: %v", err)
+	}
+
+	if name == "" {
+		builder.errorMsg = "SriovIBNetwork 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		builder.errorMsg = "SriovIBNetwork 'nsname' cannot be empty"
+	}
+
+	if targetNsname == "" {
+		builder.errorMsg = "SriovIBNetwork 'targetNsname' cannot be empty"
+	}
+
+	if resName == "" {
+		builder.errorMsg = "SriovIBNetwork 'resName' cannot be empty"
+	}
+
+	return &builder
+}
+
+// WithLinkState sets linkState parameters in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithLinkState(linkState string) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovIBNetwork %s with linkState: %s", builder.Definition.Name, linkState)
+
+	allowedLinkStates := []string{"enable", "disable", "auto"}
+
+	if !slices.Contains(allowedLinkStates, linkState) {
+		builder.errorMsg = "invalid 'linkState' parameters"
+
+		return builder
+	}
+
+	builder.Definition.Spec.LinkState = linkState
+
+	return builder
+}
+
+// WithInfinibandGUIDSupport sets infinibandGUID capabilities in the SriovIBNetwork definition spec.
+func (builder *IBNetworkBuilder) WithInfinibandGUIDSupport() *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovIBNetwork %s with infinibandGUID capability", builder.Definition.Name)
+
+	builder.Definition.Spec.Capabilities = `{ "infinibandGUID": true }`
+
+	return builder
+}
+
+// WithIPAM sets the IPAM configuration in the SriovIBNetwork definition spec, e.g. { "type": "whereabouts", ... }.
+func (builder *IBNetworkBuilder) WithIPAM(ipam string) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovIBNetwork %s with ipam: %s", builder.Definition.Name, ipam)
+
+	if ipam == "" {
+		builder.errorMsg = "SriovIBNetwork 'ipam' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.IPAM = ipam
+
+	return builder
+}
+
+// WithMetaPlugins sets the metaplugins configuration chained to the SriovIBNetwork interface.
+func (builder *IBNetworkBuilder) WithMetaPlugins(metaPlugins string) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovIBNetwork %s with metaPlugins: %s", builder.Definition.Name, metaPlugins)
+
+	if metaPlugins == "" {
+		builder.errorMsg = "SriovIBNetwork 'metaPlugins' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.MetaPluginsConfig = metaPlugins
+
+	return builder
+}
+
+// WithOptions creates SriovIBNetwork with generic mutation options.
+func (builder *IBNetworkBuilder) WithOptions(options ...IBNetworkAdditionalOptions) *IBNetworkBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovIBNetwork additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// PullIBNetwork pulls existing SriovIBNetwork from cluster.
+func PullIBNetwork(apiClient *clients.Settings, name, nsname string) (*IBNetworkBuilder, error) {
+	glog.V(100).Infof("Pulling existing SriovIBNetwork name %s under namespace %s from cluster", name, nsname)
+
+	builder := IBNetworkBuilder{
+		apiClient: apiClient,
+		Definition: &srIovV1.SriovIBNetwork{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      name,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if err := apiClient.AttachScheme(srIovV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add SriovIBNetwork scheme to client schemes")
+
+		return nil, err
+	}
+
+	if name == "" {
+		glog.V(100).Infof("The name of the SriovIBNetwork is empty")
+
+		builder.errorMsg = "SriovIBNetwork 'name' cannot be empty"
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the SriovIBNetwork is empty")
+
+		builder.errorMsg = "SriovIBNetwork 'namespace' cannot be empty"
+	}
+
+	object, err := builder.Get()
+	if err != nil {
+		if clients.IsCRDNotInstalled(err) {
+			return nil, err
+		}
+
+		return nil, fmt.Errorf("SriovIBNetwork object %s doesn't exist in namespace %s", name, nsname)
+	}
+
+	builder.Object = object
+	builder.Definition = object
+
+	return &builder, nil
+}
+
+// Get returns the SriovIBNetwork object if found.
+func (builder *IBNetworkBuilder) Get() (*srIovV1.SriovIBNetwork, error) {
+	if valid, err := builder.validate(); !valid {
+		return nil, err
+	}
+
+	glog.V(100).Infof("Collecting SriovIBNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	ibNetwork := &srIovV1.SriovIBNetwork{}
+	err := builder.apiClient.Get(context.TODO(), goclient.ObjectKey{
+		Name:      builder.Definition.Name,
+		Namespace: builder.Definition.Namespace,
+	}, ibNetwork)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return ibNetwork, nil
+}
+
+// Create generates SriovIBNetwork in a cluster and stores the created object in struct.
+func (builder *IBNetworkBuilder) Create() (*IBNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the SriovIBNetwork %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		err := builder.apiClient.Create(context.TODO(), builder.Definition)
+		if err != nil {
+			return nil, err
+		}
+
+		builder.Object = builder.Definition
+	}
+
+	return builder, nil
+}
+
+// Update renovates the existing SriovIBNetwork object with the SriovIBNetwork definition in builder.
+func (builder *IBNetworkBuilder) Update(force bool) (*IBNetworkBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the SriovIBNetwork object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update SriovIBNetwork, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	err := builder.apiClient.Update(context.TODO(), builder.Definition)
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the SriovIBNetwork object %s in namespace %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the SriovIBNetwork object %s in namespace %s, "+
+						"due to error in delete function",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	builder.Object = builder.Definition
+
+	return builder, nil
+}
+
+// Delete removes SriovIBNetwork object.
+func (builder *IBNetworkBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the SriovIBNetwork %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.Delete(context.TODO(), builder.Definition)
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Exists checks whether the given SriovIBNetwork object exists in a cluster.
+func (builder *IBNetworkBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if SriovIBNetwork %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.Get()
+
+	return err == nil || (!k8serrors.IsNotFound(err) && !clients.IsCRDNotInstalled(err))
+}
+
+// GetSriovIBNetworksGVR returns SriovIBNetwork's GroupVersionResource which could be used for Clean function.
+func GetSriovIBNetworksGVR() schema.GroupVersionResource {
+	return schema.GroupVersionResource{
+		Group: "sriovnetwork.openshift.io", Version: "v1", Resource: "sriovibnetworks",
+	}
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *IBNetworkBuilder) validate() (bool, error) {
+	resourceCRD := "SriovIBNetwork"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
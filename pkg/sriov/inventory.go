@@ -0,0 +1,211 @@
+package sriov
+
+import (
+	"sort"
+
+	"github.com/golang/glog"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"golang.org/x/exp/slices"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NICInventoryEntry provides a struct describing a single SR-IOV capable PF discovered on a node.
+type NICInventoryEntry struct {
+	NodeName   string
+	PFName     string
+	PciAddress string
+	Vendor     string
+	DeviceID   string
+	Driver     string
+	LinkSpeed  string
+	LinkType   string
+	LinkUp     bool
+	TotalVFs   int
+	NumVFs     int
+}
+
+// NICInventory provides a struct for the SR-IOV capable PFs of the cluster aggregated from SriovNetworkNodeStates.
+type NICInventory struct {
+	Entries []NICInventoryEntry
+}
+
+// NICInventoryFilter defines a predicate used to filter NICInventory entries.
+type NICInventoryFilter func(entry NICInventoryEntry) bool
+
+// DiscoverNICInventory aggregates the SriovNetworkNodeStates of the given SR-IOV operator namespace
+// into a NICInventory sorted by node and PF name.
+func DiscoverNICInventory(apiClient *clients.Settings, nsname string) (*NICInventory, error) {
+	glog.V(100).Infof("Discovering SR-IOV NIC inventory from SriovNetworkNodeStates in namespace %s", nsname)
+
+	nodeStates, err := ListNetworkNodeState(apiClient, nsname, metaV1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	inventory := &NICInventory{}
+
+	for _, nodeState := range nodeStates {
+		for _, nic := range nodeState.Objects.Status.Interfaces {
+			inventory.Entries = append(inventory.Entries, NICInventoryEntry{
+				NodeName:   nodeState.Objects.Name,
+				PFName:     nic.Name,
+				PciAddress: nic.PciAddress,
+				Vendor:     nic.Vendor,
+				DeviceID:   nic.DeviceID,
+				Driver:     nic.Driver,
+				LinkSpeed:  nic.LinkSpeed,
+				LinkType:   nic.LinkType,
+				LinkUp:     isNICUp(nic.LinkSpeed),
+				TotalVFs:   nic.TotalVfs,
+				NumVFs:     nic.NumVfs,
+			})
+		}
+	}
+
+	sort.SliceStable(inventory.Entries, func(i, j int) bool {
+		if inventory.Entries[i].NodeName != inventory.Entries[j].NodeName {
+			return inventory.Entries[i].NodeName < inventory.Entries[j].NodeName
+		}
+
+		return inventory.Entries[i].PFName < inventory.Entries[j].PFName
+	})
+
+	glog.V(100).Infof("Discovered %d SR-IOV PFs", len(inventory.Entries))
+
+	return inventory, nil
+}
+
+// Filter returns a new NICInventory containing only the entries matching all given filters.
+func (inventory *NICInventory) Filter(filters ...NICInventoryFilter) *NICInventory {
+	filtered := &NICInventory{}
+
+	for _, entry := range inventory.Entries {
+		matches := true
+
+		for _, filter := range filters {
+			if filter != nil && !filter(entry) {
+				matches = false
+
+				break
+			}
+		}
+
+		if matches {
+			filtered.Entries = append(filtered.Entries, entry)
+		}
+	}
+
+	return filtered
+}
+
+// PresentOnAtLeast returns a new NICInventory containing only the PFs whose name is present on at
+// least minNodes distinct nodes. Filters applied beforehand are taken into account, e.g.
+// inventory.Filter(UpNICs(), ByVendor("8086")).PresentOnAtLeast(2) returns up Intel PFs available on two nodes.
+func (inventory *NICInventory) PresentOnAtLeast(minNodes int) *NICInventory {
+	nodesPerPF := inventory.NodesByPFName()
+	filtered := &NICInventory{}
+
+	for _, entry := range inventory.Entries {
+		if len(nodesPerPF[entry.PFName]) >= minNodes {
+			filtered.Entries = append(filtered.Entries, entry)
+		}
+	}
+
+	return filtered
+}
+
+// NodesByPFName returns the sorted list of nodes exposing each PF name of the inventory.
+func (inventory *NICInventory) NodesByPFName() map[string][]string {
+	nodesPerPF := make(map[string][]string)
+
+	for _, entry := range inventory.Entries {
+		nodesPerPF[entry.PFName] = appendUnique(nodesPerPF[entry.PFName], entry.NodeName)
+	}
+
+	for pfName := range nodesPerPF {
+		sort.Strings(nodesPerPF[pfName])
+	}
+
+	return nodesPerPF
+}
+
+// PFNames returns the sorted list of distinct PF names of the inventory.
+func (inventory *NICInventory) PFNames() []string {
+	var pfNames []string
+
+	for _, entry := range inventory.Entries {
+		pfNames = appendUnique(pfNames, entry.PFName)
+	}
+
+	sort.Strings(pfNames)
+
+	return pfNames
+}
+
+// NodeNames returns the sorted list of distinct nodes of the inventory.
+func (inventory *NICInventory) NodeNames() []string {
+	var nodeNames []string
+
+	for _, entry := range inventory.Entries {
+		nodeNames = appendUnique(nodeNames, entry.NodeName)
+	}
+
+	sort.Strings(nodeNames)
+
+	return nodeNames
+}
+
+// UpNICs returns a NICInventoryFilter matching PFs with link in UP state.
+func UpNICs() NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.LinkUp
+	}
+}
+
+// ByVendor returns a NICInventoryFilter matching PFs of the given vendor hex code, e.g. 8086.
+func ByVendor(vendor string) NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.Vendor == vendor
+	}
+}
+
+// ByDeviceID returns a NICInventoryFilter matching PFs with the given device hex code, e.g. 158b.
+func ByDeviceID(deviceID string) NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.DeviceID == deviceID
+	}
+}
+
+// ByDriver returns a NICInventoryFilter matching PFs bound to the given driver, e.g. i40e.
+func ByDriver(driver string) NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.Driver == driver
+	}
+}
+
+// ByNode returns a NICInventoryFilter matching PFs of the given node.
+func ByNode(nodeName string) NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.NodeName == nodeName
+	}
+}
+
+// WithMinTotalVFs returns a NICInventoryFilter matching PFs supporting at least the given number of VFs.
+func WithMinTotalVFs(totalVFs int) NICInventoryFilter {
+	return func(entry NICInventoryEntry) bool {
+		return entry.TotalVFs >= totalVFs
+	}
+}
+
+// isNICUp checks the PF link state based on the link speed reported in SriovNetworkNodeState.
+func isNICUp(linkSpeed string) bool {
+	return linkSpeed != "" && linkSpeed != "-1 Mb/s"
+}
+
+func appendUnique(list []string, value string) []string {
+	if slices.Contains(list, value) {
+		return list
+	}
+
+	return append(list, value)
+}
@@ -51,6 +51,12 @@ func NewNetworkBuilder(
 		},
 	}
 
+	if err := apiClient.AttachScheme(srIovV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add SriovNetwork scheme to client schemes")
+
+		builder.errorMsg = fmt.Sprintf("failed to add SriovNetwork scheme to client schemes: %v", err)
+	}
+
 	if name == "" {
 		builder.errorMsg = "SrIovNetwork 'name' cannot be empty"
 	}
@@ -240,6 +246,12 @@ func PullNetwork(apiClient *clients.Settings, name, nsname string) (*NetworkBuil
 		},
 	}
 
+	if err := apiClient.AttachScheme(srIovV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add SriovNetwork scheme to client schemes")
+
+		return nil, err
+	}
+
 	if name == "" {
 		glog.V(100).Infof("The name of the sriovnetwork is empty")
 
@@ -320,6 +332,12 @@ func (builder *NetworkBuilder) Exists() bool {
 func List(apiClient *clients.Settings, nsname string, options metaV1.ListOptions) ([]*NetworkBuilder, error) {
 	glog.V(100).Infof("Listing sriov networks in the namespace %s with the options %v", nsname, options)
 
+	if err := apiClient.AttachScheme(srIovV1.AddToScheme); err != nil {
+		glog.V(100).Infof("Failed to add SriovNetwork scheme to client schemes")
+
+		return nil, err
+	}
+
 	if nsname == "" {
 		glog.V(100).Infof("sriov network 'nsname' parameter can not be empty")
 
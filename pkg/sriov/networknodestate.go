@@ -90,7 +90,7 @@ func (builder *NetworkNodeStateBuilder) GetUpNICs() (srIovV1.InterfaceExts, erro
 	var sriovNicsUp srIovV1.InterfaceExts
 
 	for _, nic := range sriovNics {
-		if nic.LinkSpeed != "" && nic.LinkSpeed != "-1 Mb/s" {
+		if isNICUp(nic.LinkSpeed) {
 			glog.V(100).Infof("Interface %s is UP on node %s. Append to list", nic.Name, builder.nodeName)
 			sriovNicsUp = append(sriovNicsUp, nic)
 		}
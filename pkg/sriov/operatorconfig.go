@@ -0,0 +1,393 @@
+package sriov
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+	srIovV1 "github.com/k8snetworkplumbingwg/sriov-network-operator/api/v1"
+	"github.com/openshift-kni/eco-goinfra/pkg/clients"
+	"github.com/openshift-kni/eco-goinfra/pkg/daemonset"
+	"github.com/openshift-kni/eco-goinfra/pkg/msg"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+const (
+	// operatorConfigName is the only SriovOperatorConfig name reconciled by the SR-IOV operator.
+	operatorConfigName = "default"
+	// injectorDaemonSetName is the name of the network resources injector webhook DaemonSet.
+	injectorDaemonSetName = "network-resources-injector"
+	// operatorWebhookDaemonSetName is the name of the operator admission controller webhook DaemonSet.
+	operatorWebhookDaemonSetName = "operator-webhook"
+	// configDaemonSetName is the name of the SR-IOV config daemon DaemonSet.
+	configDaemonSetName = "sriov-network-config-daemon"
+	// daemonSetReadyTimeout is how long a single readiness check of an operator DaemonSet may take.
+	daemonSetReadyTimeout = time.Second
+)
+
+// OperatorConfigBuilder provides struct for SriovOperatorConfig object containing connection to the cluster and the
+// SriovOperatorConfig definitions.
+type OperatorConfigBuilder struct {
+	// SriovOperatorConfig definition. Used to create SriovOperatorConfig object.
+	Definition *srIovV1.SriovOperatorConfig
+	// Created SriovOperatorConfig object.
+	Object *srIovV1.SriovOperatorConfig
+	// Used in functions that define or mutate SriovOperatorConfig definition. errorMsg is processed before the
+	// SriovOperatorConfig object is created.
+	errorMsg string
+	// apiClient opens api connection to the cluster.
+	apiClient *clients.Settings
+}
+
+// OperatorConfigAdditionalOptions additional options for SriovOperatorConfig object.
+type OperatorConfigAdditionalOptions func(builder *OperatorConfigBuilder) (*OperatorConfigBuilder, error)
+
+// NewOperatorConfigBuilder creates a new instance of OperatorConfigBuilder. The SR-IOV operator only reconciles
+// the SriovOperatorConfig named default, therefore the name is not configurable.
+func NewOperatorConfigBuilder(apiClient *clients.Settings, nsname string) *OperatorConfigBuilder {
+	glog.V(100).Infof(
+		"Initializing new OperatorConfigBuilder structure with the following params: %s", nsname)
+
+	builder := OperatorConfigBuilder{
+		apiClient: apiClient,
+		Definition: &srIovV1.SriovOperatorConfig{
+			ObjectMeta: metaV1.ObjectMeta{
+				Name:      operatorConfigName,
+				Namespace: nsname,
+			},
+		},
+	}
+
+	if nsname == "" {
+		glog.V(100).Infof("The namespace of the SriovOperatorConfig is empty")
+
+		builder.errorMsg = "SriovOperatorConfig 'nsname' cannot be empty"
+	}
+
+	return &builder
+}
+
+// PullOperatorConfig pulls existing SriovOperatorConfig from cluster.
+func PullOperatorConfig(apiClient *clients.Settings, nsname string) (*OperatorConfigBuilder, error) {
+	glog.V(100).Infof("Pulling existing SriovOperatorConfig %s under namespace %s from cluster",
+		operatorConfigName, nsname)
+
+	builder := NewOperatorConfigBuilder(apiClient, nsname)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("SriovOperatorConfig object %s doesn't exist in namespace %s", operatorConfigName, nsname)
+	}
+
+	builder.Definition = builder.Object
+
+	return builder, nil
+}
+
+// WithInjector enables or disables the network resources injector webhook in the SriovOperatorConfig.
+func (builder *OperatorConfigBuilder) WithInjector(enabled bool) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovOperatorConfig with enableInjector: %t", enabled)
+
+	builder.Definition.Spec.EnableInjector = &enabled
+
+	return builder
+}
+
+// WithOperatorWebhook enables or disables the operator admission controller webhook in the SriovOperatorConfig.
+func (builder *OperatorConfigBuilder) WithOperatorWebhook(enabled bool) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovOperatorConfig with enableOperatorWebhook: %t", enabled)
+
+	builder.Definition.Spec.EnableOperatorWebhook = &enabled
+
+	return builder
+}
+
+// WithConfigDaemonNodeSelector sets the nodeSelector of the SR-IOV config daemon in the SriovOperatorConfig.
+func (builder *OperatorConfigBuilder) WithConfigDaemonNodeSelector(
+	nodeSelector map[string]string) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovOperatorConfig with configDaemonNodeSelector: %v", nodeSelector)
+
+	if len(nodeSelector) == 0 {
+		builder.errorMsg = "SriovOperatorConfig 'nodeSelector' cannot be empty map"
+
+		return builder
+	}
+
+	builder.Definition.Spec.ConfigDaemonNodeSelector = nodeSelector
+
+	return builder
+}
+
+// WithDisableDrain sets the disableDrain flag in the SriovOperatorConfig.
+func (builder *OperatorConfigBuilder) WithDisableDrain(disableDrain bool) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovOperatorConfig with disableDrain: %t", disableDrain)
+
+	builder.Definition.Spec.DisableDrain = disableDrain
+
+	return builder
+}
+
+// WithLogLevel sets the operator log level in the SriovOperatorConfig. Allowed values are between 0 and 2.
+func (builder *OperatorConfigBuilder) WithLogLevel(logLevel int) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovOperatorConfig with logLevel: %d", logLevel)
+
+	if logLevel < 0 || logLevel > 2 {
+		builder.errorMsg = fmt.Sprintf("invalid logLevel %d, allowed logLevel values are between 0-2", logLevel)
+
+		return builder
+	}
+
+	builder.Definition.Spec.LogLevel = logLevel
+
+	return builder
+}
+
+// WithOptions creates SriovOperatorConfig with generic mutation options.
+func (builder *OperatorConfigBuilder) WithOptions(options ...OperatorConfigAdditionalOptions) *OperatorConfigBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Setting SriovOperatorConfig additional options")
+
+	for _, option := range options {
+		if option != nil {
+			builder, err := option(builder)
+
+			if err != nil {
+				glog.V(100).Infof("Error occurred in mutation function")
+
+				builder.errorMsg = err.Error()
+
+				return builder
+			}
+		}
+	}
+
+	return builder
+}
+
+// Create generates an SriovOperatorConfig in the cluster and stores the created object in struct.
+func (builder *OperatorConfigBuilder) Create() (*OperatorConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Creating the SriovOperatorConfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		var err error
+		builder.Object, err = builder.apiClient.SriovOperatorConfigs(builder.Definition.Namespace).Create(
+			context.TODO(), builder.Definition, metaV1.CreateOptions{})
+
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return builder, nil
+}
+
+// Update renovates the existing SriovOperatorConfig object with the SriovOperatorConfig definition in builder.
+func (builder *OperatorConfigBuilder) Update(force bool) (*OperatorConfigBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the SriovOperatorConfig object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update SriovOperatorConfig, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	var err error
+	builder.Object, err = builder.apiClient.SriovOperatorConfigs(builder.Definition.Namespace).Update(
+		context.TODO(), builder.Definition, metaV1.UpdateOptions{})
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the SriovOperatorConfig object %s in namespace %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the SriovOperatorConfig object %s in namespace %s, "+
+						"due to error in delete function",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	return builder, nil
+}
+
+// Delete removes an SriovOperatorConfig object.
+func (builder *OperatorConfigBuilder) Delete() error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Deleting the SriovOperatorConfig %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil
+	}
+
+	err := builder.apiClient.SriovOperatorConfigs(builder.Definition.Namespace).Delete(
+		context.TODO(), builder.Definition.Name, metaV1.DeleteOptions{})
+
+	if err != nil {
+		return err
+	}
+
+	builder.Object = nil
+
+	return nil
+}
+
+// Exists checks whether the given SriovOperatorConfig object exists in the cluster.
+func (builder *OperatorConfigBuilder) Exists() bool {
+	if valid, _ := builder.validate(); !valid {
+		return false
+	}
+
+	glog.V(100).Infof("Checking if SriovOperatorConfig %s exists in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	var err error
+	builder.Object, err = builder.apiClient.SriovOperatorConfigs(builder.Definition.Namespace).Get(
+		context.Background(), builder.Definition.Name, metaV1.GetOptions{})
+
+	return err == nil || !k8serrors.IsNotFound(err)
+}
+
+// WaitUntilOperatorPodsReconciled waits for the duration of the defined timeout or until the operator pods reflect
+// the SriovOperatorConfig definition: the injector and operator webhook DaemonSets are present and ready only when
+// enabled, and the config daemon DaemonSet uses the configured nodeSelector and has all its pods updated and ready.
+func (builder *OperatorConfigBuilder) WaitUntilOperatorPodsReconciled(timeout time.Duration) error {
+	if valid, err := builder.validate(); !valid {
+		return err
+	}
+
+	glog.V(100).Infof("Waiting for the defined period until SR-IOV operator pods in namespace %s "+
+		"reflect the SriovOperatorConfig", builder.Definition.Namespace)
+
+	return wait.PollImmediate(time.Second, timeout, func() (bool, error) {
+		for daemonSetName, enabled := range map[string]*bool{
+			injectorDaemonSetName:        builder.Definition.Spec.EnableInjector,
+			operatorWebhookDaemonSetName: builder.Definition.Spec.EnableOperatorWebhook,
+		} {
+			if reconciled := builder.isWebhookReconciled(daemonSetName, enabled); !reconciled {
+				return false, nil
+			}
+		}
+
+		configDaemon, err := daemonset.Pull(builder.apiClient, configDaemonSetName, builder.Definition.Namespace)
+		if err != nil {
+			glog.V(100).Infof("Failed to get DaemonSet %s due to %v", configDaemonSetName, err)
+
+			return false, nil
+		}
+
+		for key, value := range builder.Definition.Spec.ConfigDaemonNodeSelector {
+			if configDaemon.Object.Spec.Template.Spec.NodeSelector[key] != value {
+				glog.V(100).Infof("DaemonSet %s does not reflect nodeSelector %s=%s yet", configDaemonSetName, key, value)
+
+				return false, nil
+			}
+		}
+
+		return configDaemon.IsReady(daemonSetReadyTimeout), nil
+	})
+}
+
+// isWebhookReconciled checks that the webhook DaemonSet is ready when enabled and removed when disabled.
+// A nil flag means the operator default, which enables the webhook.
+func (builder *OperatorConfigBuilder) isWebhookReconciled(daemonSetName string, enabled *bool) bool {
+	if enabled != nil && !*enabled {
+		_, err := builder.apiClient.AppsV1Interface.DaemonSets(builder.Definition.Namespace).Get(
+			context.TODO(), daemonSetName, metaV1.GetOptions{})
+
+		return k8serrors.IsNotFound(err)
+	}
+
+	daemonSet, err := daemonset.Pull(builder.apiClient, daemonSetName, builder.Definition.Namespace)
+	if err != nil {
+		glog.V(100).Infof("Failed to get DaemonSet %s due to %v", daemonSetName, err)
+
+		return false
+	}
+
+	return daemonSet.IsReady(daemonSetReadyTimeout)
+}
+
+// validate will check that the builder and builder definition are properly initialized before
+// accessing any member fields.
+func (builder *OperatorConfigBuilder) validate() (bool, error) {
+	resourceCRD := "SriovOperatorConfig"
+
+	if builder == nil {
+		glog.V(100).Infof("The %s builder is uninitialized", resourceCRD)
+
+		return false, fmt.Errorf("error: received nil %s builder", resourceCRD)
+	}
+
+	if builder.Definition == nil {
+		glog.V(100).Infof("The %s is undefined", resourceCRD)
+
+		builder.errorMsg = msg.UndefinedCrdObjectErrString(resourceCRD)
+	}
+
+	if builder.apiClient == nil {
+		glog.V(100).Infof("The %s builder apiclient is nil", resourceCRD)
+
+		builder.errorMsg = fmt.Sprintf("%s builder cannot have nil apiClient", resourceCRD)
+	}
+
+	if builder.errorMsg != "" {
+		glog.V(100).Infof("The %s builder has error message: %s", resourceCRD, builder.errorMsg)
+
+		return false, fmt.Errorf(builder.errorMsg)
+	}
+
+	return true, nil
+}
@@ -3,6 +3,8 @@ package sriov
 import (
 	"context"
 	"fmt"
+	"regexp"
+	"strings"
 
 	"github.com/golang/glog"
 	"github.com/openshift-kni/eco-goinfra/pkg/msg"
@@ -14,6 +16,11 @@ import (
 	metaV1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+var (
+	hexCodeRegex    = regexp.MustCompile(`^[0-9a-fA-F]{4}$`)
+	pciAddressRegex = regexp.MustCompile(`^[0-9a-fA-F]{4}:[0-9a-fA-F]{2}:[0-9a-fA-F]{2}\.[0-7]$`)
+)
+
 // PolicyBuilder provides struct for srIovPolicy object containing connection to the cluster and the srIovPolicy
 // definitions.
 type PolicyBuilder struct {
@@ -187,6 +194,193 @@ func (builder *PolicyBuilder) WithExternallyCreated(externallyCreated bool) *Pol
 	return builder
 }
 
+// WithVendor sets the vendor hex code of the SR-IOV PF in the SriovNetworkNodePolicy nicSelector, e.g. 8086.
+func (builder *PolicyBuilder) WithVendor(vendor string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector vendor: %s",
+		builder.Definition.Name, vendor)
+
+	if !hexCodeRegex.MatchString(vendor) {
+		builder.errorMsg = fmt.Sprintf("invalid vendor %q, vendor should be a 4 digit hex code", vendor)
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector.Vendor = vendor
+
+	return builder
+}
+
+// WithDeviceID sets the device hex code of the SR-IOV PF in the SriovNetworkNodePolicy nicSelector, e.g. 158b.
+func (builder *PolicyBuilder) WithDeviceID(deviceID string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector deviceID: %s",
+		builder.Definition.Name, deviceID)
+
+	if !hexCodeRegex.MatchString(deviceID) {
+		builder.errorMsg = fmt.Sprintf("invalid deviceID %q, deviceID should be a 4 digit hex code", deviceID)
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector.DeviceID = deviceID
+
+	return builder
+}
+
+// WithRootDevices sets the PCI addresses of the SR-IOV PFs in the SriovNetworkNodePolicy nicSelector.
+func (builder *PolicyBuilder) WithRootDevices(rootDevices []string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector rootDevices: %v",
+		builder.Definition.Name, rootDevices)
+
+	if len(rootDevices) == 0 {
+		builder.errorMsg = "SriovNetworkNodePolicy 'rootDevices' cannot be empty list"
+
+		return builder
+	}
+
+	for _, rootDevice := range rootDevices {
+		if !pciAddressRegex.MatchString(rootDevice) {
+			builder.errorMsg = fmt.Sprintf("invalid rootDevice %q, expected PCI address e.g. 0000:3b:00.0", rootDevice)
+
+			return builder
+		}
+	}
+
+	builder.Definition.Spec.NicSelector.RootDevices = rootDevices
+
+	return builder
+}
+
+// WithNetFilter sets the infrastructure networking selection filter in the SriovNetworkNodePolicy nicSelector.
+func (builder *PolicyBuilder) WithNetFilter(netFilter string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector netFilter: %s",
+		builder.Definition.Name, netFilter)
+
+	if netFilter == "" {
+		builder.errorMsg = "SriovNetworkNodePolicy 'netFilter' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector.NetFilter = netFilter
+
+	return builder
+}
+
+// WithNicSelector replaces the nicSelector of the SriovNetworkNodePolicy, e.g. with one built by NewNicSelector.
+func (builder *PolicyBuilder) WithNicSelector(nicSelector srIovV1.SriovNetworkNicSelector) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with nicSelector: %v",
+		builder.Definition.Name, nicSelector)
+
+	if len(nicSelector.PfNames) == 0 && len(nicSelector.RootDevices) == 0 &&
+		nicSelector.Vendor == "" && nicSelector.DeviceID == "" && nicSelector.NetFilter == "" {
+		builder.errorMsg = "SriovNetworkNodePolicy 'nicSelector' cannot be empty"
+
+		return builder
+	}
+
+	builder.Definition.Spec.NicSelector = nicSelector
+
+	return builder
+}
+
+// WithPFVFRange sets a specific VF range for the given PF only, leaving the partitions of other PFs untouched.
+func (builder *PolicyBuilder) WithPFVFRange(pfName string, firstVF, lastVF int) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with VF range %d-%d on PF %s",
+		builder.Definition.Name, firstVF, lastVF, pfName)
+
+	if firstVF > lastVF {
+		builder.errorMsg = "firstPF argument can not be greater than lastPF"
+	}
+
+	if lastVF > 63 {
+		builder.errorMsg = "lastVF can not be greater than 63"
+	}
+
+	if builder.errorMsg != "" {
+		return builder
+	}
+
+	for index, pf := range builder.Definition.Spec.NicSelector.PfNames {
+		if strings.Split(pf, "#")[0] == pfName {
+			builder.Definition.Spec.NicSelector.PfNames[index] = fmt.Sprintf("%s#%d-%d", pfName, firstVF, lastVF)
+
+			return builder
+		}
+	}
+
+	builder.errorMsg = fmt.Sprintf("PF %s is not part of the SriovNetworkNodePolicy nicSelector", pfName)
+
+	return builder
+}
+
+// WithESwitchMode sets the NIC eSwitch mode in SriovNetworkNodePolicy object. Allowed modes are legacy and switchdev.
+func (builder *PolicyBuilder) WithESwitchMode(eSwitchMode string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with eSwitchMode: %s",
+		builder.Definition.Name, eSwitchMode)
+
+	allowedESwitchModes := []string{"legacy", "switchdev"}
+
+	if !slices.Contains(allowedESwitchModes, eSwitchMode) {
+		builder.errorMsg = "invalid eSwitch mode, allowed eSwitchMode values are: legacy or switchdev"
+
+		return builder
+	}
+
+	builder.Definition.Spec.EswitchMode = eSwitchMode
+
+	return builder
+}
+
+// WithLinkType sets the NIC link type in SriovNetworkNodePolicy object. Allowed link types are eth and ib.
+func (builder *PolicyBuilder) WithLinkType(linkType string) *PolicyBuilder {
+	if valid, _ := builder.validate(); !valid {
+		return builder
+	}
+
+	glog.V(100).Infof("Redefining SriovNetworkNodePolicy %s with linkType: %s",
+		builder.Definition.Name, linkType)
+
+	allowedLinkTypes := []string{"eth", "ETH", "ib", "IB"}
+
+	if !slices.Contains(allowedLinkTypes, linkType) {
+		builder.errorMsg = "invalid link type, allowed linkType values are: eth, ETH, ib or IB"
+
+		return builder
+	}
+
+	builder.Definition.Spec.LinkType = linkType
+
+	return builder
+}
+
 // WithOptions creates SriovNetworkNodePolicy with generic mutation options.
 func (builder *PolicyBuilder) WithOptions(options ...PolicyAdditionalOptions) *PolicyBuilder {
 	if valid, _ := builder.validate(); !valid {
@@ -212,6 +406,28 @@ func (builder *PolicyBuilder) WithOptions(options ...PolicyAdditionalOptions) *P
 	return builder
 }
 
+// NewNicSelector returns a SriovNetworkNicSelector matching exactly the given PF discovered in
+// a SriovNetworkNodeState, e.g. one returned by NetworkNodeStateBuilder.GetUpNICs.
+func NewNicSelector(nic srIovV1.InterfaceExt) srIovV1.SriovNetworkNicSelector {
+	glog.V(100).Infof("Building nicSelector for PF %s with PCI address %s", nic.Name, nic.PciAddress)
+
+	nicSelector := srIovV1.SriovNetworkNicSelector{
+		Vendor:    nic.Vendor,
+		DeviceID:  nic.DeviceID,
+		NetFilter: nic.NetFilter,
+	}
+
+	if nic.Name != "" {
+		nicSelector.PfNames = []string{nic.Name}
+	}
+
+	if nic.PciAddress != "" {
+		nicSelector.RootDevices = []string{nic.PciAddress}
+	}
+
+	return nicSelector
+}
+
 // PullPolicy pulls existing sriovnetworknodepolicy from cluster.
 func PullPolicy(apiClient *clients.Settings, name, nsname string) (*PolicyBuilder, error) {
 	glog.V(100).Infof("Pulling existing sriovnetworknodepolicy name %s under namespace %s from cluster", name, nsname)
@@ -267,6 +483,54 @@ func (builder *PolicyBuilder) Create() (*PolicyBuilder, error) {
 	return builder, nil
 }
 
+// Update renovates the existing SriovNetworkNodePolicy object with the SriovNetworkNodePolicy definition in builder.
+func (builder *PolicyBuilder) Update(force bool) (*PolicyBuilder, error) {
+	if valid, err := builder.validate(); !valid {
+		return builder, err
+	}
+
+	glog.V(100).Infof("Updating the SriovNetworkNodePolicy object %s in namespace %s",
+		builder.Definition.Name, builder.Definition.Namespace)
+
+	if !builder.Exists() {
+		return nil, fmt.Errorf("failed to update SriovNetworkNodePolicy, object does not exist on cluster")
+	}
+
+	builder.Definition.ResourceVersion = builder.Object.ResourceVersion
+
+	var err error
+	builder.Object, err = builder.apiClient.SriovNetworkNodePolicies(builder.Definition.Namespace).Update(
+		context.TODO(), builder.Definition, metaV1.UpdateOptions{})
+
+	if err != nil {
+		if force {
+			glog.V(100).Infof(
+				"Failed to update the SriovNetworkNodePolicy object %s in namespace %s. "+
+					"Note: Force flag set, executed delete/create methods instead",
+				builder.Definition.Name, builder.Definition.Namespace)
+
+			err = builder.Delete()
+
+			if err != nil {
+				glog.V(100).Infof(
+					"Failed to update the SriovNetworkNodePolicy object %s in namespace %s, "+
+						"due to error in delete function",
+					builder.Definition.Name, builder.Definition.Namespace)
+
+				return nil, err
+			}
+
+			builder.Definition.ResourceVersion = ""
+
+			return builder.Create()
+		}
+
+		return nil, err
+	}
+
+	return builder, nil
+}
+
 // Delete removes an SriovNetworkNodePolicy object.
 func (builder *PolicyBuilder) Delete() error {
 	if valid, err := builder.validate(); !valid {